@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/networkservicemesh/integration-tests/extensions/logs"
+)
+
+var (
+	collectorsMu    sync.Mutex
+	suiteCollectors = make(map[string][]string)
+)
+
+// EnableCollectors enables logs collectors of technologies used by the suite, e.g. vpp, ovs or spire. Collectors run for dumps
+// of the suite and its tests. It should be called from SetupSuite. LOGS_COLLECTORS overrides collectors of suites if it is set.
+// Note: collectors are kept by the name of the suite level test, because parallel.Run runs tests on new instances of the suite.
+func (s *Suite) EnableCollectors(names ...string) {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+
+	suiteCollectors[s.T().Name()] = append(suiteCollectors[s.T().Name()], names...)
+}
+
+// collectorsOf returns the dump option with collectors enabled by the suite and suites it runs in.
+func collectorsOf(suite string) logs.Option {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+
+	var result []string
+	for name := suite; ; name = name[:strings.LastIndex(name, "/")] {
+		result = append(result, suiteCollectors[name]...)
+		if !strings.Contains(name, "/") {
+			break
+		}
+	}
+	return logs.WithCollectors(result...)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/integration-tests/extensions/logs"
+)
+
+const (
+	textFormat   = "text"
+	jsonFormat   = "json"
+	cleanupPhase = "cleanup"
+)
+
+// ProgressConfig is env config for progress logs of suites.
+type ProgressConfig struct {
+	Format string `default:"text" desc:"Format of progress logs of suites, tests, steps and cleanups: text or json"`
+}
+
+var (
+	progressOnce   sync.Once
+	progressLogger *logrus.Logger
+
+	cleanupsMu sync.Mutex
+	// cleanups are start times of cleanups of tests and suites. finished is true once finishCleanup is registered for t.
+	cleanups = make(map[*testing.T]*cleanup)
+)
+
+type cleanup struct {
+	start    time.Time
+	finished bool
+}
+
+// progress returns the logger of suite transitions. Entries have fields suite, test, step, cluster, namespace and duration
+// so a single test can be filtered out of a parallel run.
+func progress() *logrus.Logger {
+	progressOnce.Do(func() {
+		var config ProgressConfig
+		if err := envconfig.Usage("progress", &config); err != nil {
+			logrus.Fatal(err.Error())
+		}
+		if err := envconfig.Process("progress", &config); err != nil {
+			logrus.Fatal(err.Error())
+		}
+
+		progressLogger = &logrus.Logger{
+			Out:   os.Stderr,
+			Level: logrus.InfoLevel,
+			Hooks: make(logrus.LevelHooks),
+		}
+		switch config.Format {
+		case textFormat:
+			progressLogger.Formatter = &logrus.TextFormatter{DisableQuote: true}
+		case jsonFormat:
+			progressLogger.Formatter = &logrus.JSONFormatter{}
+		default:
+			logrus.Fatalf("Unknown progress format %v, expected %v or %v", config.Format, textFormat, jsonFormat)
+		}
+	})
+	return progressLogger
+}
+
+// testNames returns names of the suite and the test of t. testName is the name of the test passed to BeforeTest.
+// Test name is empty for the suite level t.
+func testNames(testName string, t *testing.T) (suite, test string) {
+	if testName == "" || !strings.HasSuffix(t.Name(), "/"+testName) {
+		return t.Name(), ""
+	}
+	return strings.TrimSuffix(t.Name(), "/"+testName), testName
+}
+
+// onCleanupFinished registers f to run after all cleanups of t registered later, i.e. cleanups of the test or the suite.
+// f gets the duration of the cleanup. Parents of generated suites share t with the suite, so only the first f is registered.
+func onCleanupFinished(t *testing.T, f func(duration time.Duration)) {
+	cleanupsMu.Lock()
+	defer cleanupsMu.Unlock()
+
+	if c := cleanups[t]; c != nil && c.finished {
+		return
+	}
+	cleanups[t] = &cleanup{finished: true}
+	t.Cleanup(func() {
+		cleanupsMu.Lock()
+		var c = cleanups[t]
+		delete(cleanups, t)
+		cleanupsMu.Unlock()
+
+		var duration time.Duration
+		if !c.start.IsZero() {
+			duration = time.Since(c.start)
+		}
+		f(duration)
+	})
+}
+
+// startCleanup marks steps of t that run after this call as cleanup steps.
+func startCleanup(t *testing.T) {
+	cleanupsMu.Lock()
+	defer cleanupsMu.Unlock()
+
+	if cleanups[t] == nil {
+		cleanups[t] = new(cleanup)
+	}
+	cleanups[t].start = time.Now()
+}
+
+func phase(t *testing.T) string {
+	cleanupsMu.Lock()
+	defer cleanupsMu.Unlock()
+
+	if c := cleanups[t]; c != nil && !c.start.IsZero() {
+		return cleanupPhase
+	}
+	return ""
+}
+
+// stepFields returns fields of the step log entry.
+func (r *Runner) stepFields(step *logs.Step) logrus.Fields {
+	var kubeConfig = logs.CommandKubeConfig(step.Command)
+	if kubeConfig == "" {
+		kubeConfig = r.kubeConfig
+	}
+
+	var fields = suiteFields(r.suite, r.test)
+	fields["step"] = step.Index
+	fields["cluster"] = logs.ClusterName(kubeConfig)
+	if ns := logs.CommandNamespace(step.Command); ns != "" {
+		fields["namespace"] = ns
+	}
+	if p := phase(r.t); p != "" {
+		fields["phase"] = p
+	}
+	return fields
+}
+
+func suiteFields(suite, test string) logrus.Fields {
+	var fields = logrus.Fields{"suite": suite}
+	if test != "" {
+		fields["test"] = test
+	}
+	return fields
+}
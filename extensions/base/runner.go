@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/gotestmd/pkg/suites/shell"
+	"github.com/networkservicemesh/integration-tests/extensions/capture"
+	"github.com/networkservicemesh/integration-tests/extensions/logs"
+)
+
+// outputScript runs the command in the shell of the runner, so variables and dir changes of the command persist.
+// Output and exit codes of attempts are stored into files of the dir and the output is printed back for shell.Runner.
+const outputScript = `{
+%[1]v
+} >%[2]v/stdout 2>%[2]v/stderr; __step_rc=$?; echo $__step_rc >>%[2]v/codes; cat %[2]v/stdout; cat %[2]v/stderr >&2; (exit $__step_rc)`
+
+var (
+	stepsMu sync.Mutex
+	steps   = make(map[*testing.T][]logs.Step)
+	// stepIndexes are indexes of next steps. They are kept when steps are taken, so steps of cleanups follow steps of the test.
+	stepIndexes = make(map[*testing.T]int)
+)
+
+// Runner runs commands of generated tests by shell.Runner. It also records executed steps
+// and captures traffic around connectivity steps if capture is enabled.
+type Runner struct {
+	*shell.Runner
+	t     *testing.T
+	suite string
+	test  string
+	// kubeConfig is the kubeconfig used by commands without --kubeconfig flag.
+	kubeConfig string
+	// outputDir keeps output of steps while they run.
+	outputDir string
+}
+
+// Runner creates a runner for the dir. Relative dirs are resolved from the root of the module.
+func (s *Suite) Runner(dir string, env ...string) *Runner {
+	var t = s.T()
+	result := &Runner{
+		Runner:     s.Suite.Runner(dir, env...),
+		t:          t,
+		kubeConfig: os.Getenv("KUBECONFIG"),
+	}
+	result.suite, result.test = testNames(s.testName, t)
+	for _, e := range env {
+		if strings.HasPrefix(e, "KUBECONFIG=") {
+			result.kubeConfig = strings.TrimPrefix(e, "KUBECONFIG=")
+		}
+	}
+
+	var err error
+	if result.outputDir, err = os.MkdirTemp("", "steps-"); err != nil {
+		s.FailNowf("can't create dir for output of steps", "%v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(result.outputDir)
+	})
+
+	return result
+}
+
+// Run runs the command by shell.Runner and records it as a step of the test.
+func (r *Runner) Run(cmd string) {
+	var step = logs.Step{Index: stepIndex(r.t), Dir: r.Dir(), Command: cmd, Start: time.Now(), Failed: true}
+	var fields = r.stepFields(&step)
+	progress().WithFields(fields).Info("step started")
+	var c = capture.Start(cmd)
+	// Note: shell.Runner fails the test by FailNow if the command doesn't succeed, so the step is failed unless Run returns
+	var outputDir = filepath.Join(r.outputDir, strconv.Itoa(step.Index))
+	defer func() {
+		step.Duration = time.Since(step.Start)
+		step.Captures = c.Stop(step.Failed)
+		readOutput(outputDir, &step)
+		recordStep(r.t, &step)
+		fields["duration"], fields["exitCode"], fields["attempts"] = step.Duration, step.ExitCode, step.Attempts
+		if step.Failed {
+			progress().WithFields(fields).Error("step failed")
+		} else {
+			progress().WithFields(fields).Info("step finished")
+		}
+	}()
+
+	if err := os.MkdirAll(outputDir, 0o750); err != nil {
+		r.t.Logf("Output of the step is not recorded. Error: %v", err)
+		r.Runner.Run(cmd)
+	} else {
+		r.Runner.Run(fmt.Sprintf(outputScript, cmd, outputDir))
+	}
+	step.Failed = false
+}
+
+// readOutput sets output of the last attempt of the step, its exit code and the number of attempts.
+func readOutput(dir string, step *logs.Step) {
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	if b, err := os.ReadFile(filepath.Clean(filepath.Join(dir, "codes"))); err == nil {
+		var codes = strings.Fields(string(b))
+		step.Attempts = len(codes)
+		if len(codes) > 0 {
+			step.ExitCode, _ = strconv.Atoi(codes[len(codes)-1])
+		}
+	}
+	if b, err := os.ReadFile(filepath.Clean(filepath.Join(dir, "stdout"))); err == nil {
+		step.Stdout = strings.TrimSpace(string(b))
+	}
+	if b, err := os.ReadFile(filepath.Clean(filepath.Join(dir, "stderr"))); err == nil {
+		step.Stderr = strings.TrimSpace(string(b))
+	}
+}
+
+// stepIndex returns the index of the next step of t.
+func stepIndex(t *testing.T) int {
+	stepsMu.Lock()
+	defer stepsMu.Unlock()
+
+	var result = stepIndexes[t]
+	stepIndexes[t]++
+	return result
+}
+
+// forgetStepIndex forgets the index of the next step of t when all steps of t have finished.
+func forgetStepIndex(t *testing.T) {
+	stepsMu.Lock()
+	defer stepsMu.Unlock()
+
+	delete(stepIndexes, t)
+}
+
+func recordStep(t *testing.T, step *logs.Step) {
+	stepsMu.Lock()
+	defer stepsMu.Unlock()
+
+	steps[t] = append(steps[t], *step)
+}
+
+// dropSteps forgets steps recorded for the t and removes their pcaps. Used for steps that are never saved.
+func dropSteps(t *testing.T) {
+	for _, step := range takeSteps(t) {
+		for _, p := range step.Captures {
+			_ = os.RemoveAll(filepath.Dir(p))
+		}
+	}
+}
+
+// takeSteps returns steps recorded for the t and forgets them.
+func takeSteps(t *testing.T) []logs.Step {
+	stepsMu.Lock()
+	defer stepsMu.Unlock()
+
+	result := steps[t]
+	delete(steps, t)
+	return result
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Runner_ShouldRecordOutputAndAttempts(t *testing.T) {
+	var s = new(Suite)
+	s.SetT(t)
+
+	var r = s.Runner(t.TempDir())
+	r.Run("ATTEMPT=0")
+	r.Run(`ATTEMPT=$((ATTEMPT+1))
+echo "attempt $ATTEMPT"
+echo "not ready" >&2
+[ $ATTEMPT -ge 3 ]`)
+
+	var steps = takeSteps(t)
+	require.Len(t, steps, 2)
+	require.Equal(t, 1, steps[0].Attempts)
+	require.Equal(t, 3, steps[1].Attempts)
+	require.Equal(t, 0, steps[1].ExitCode)
+	require.Equal(t, "attempt 3", steps[1].Stdout)
+	require.Equal(t, "not ready", steps[1].Stderr)
+	require.False(t, steps[1].Failed)
+}
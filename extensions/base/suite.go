@@ -1,6 +1,6 @@
 // Copyright (c) 2021-2023 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2023-2024 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -21,7 +21,13 @@ package base
 
 import (
 	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"testing"
+	"time"
 
 	"github.com/networkservicemesh/gotestmd/pkg/suites/shell"
 	"github.com/networkservicemesh/integration-tests/extensions/checkout"
@@ -35,21 +41,135 @@ type Suite struct {
 	// Add other extensions here
 	checkout checkout.Suite
 	prefetch prefetch.Suite
+
+	// testName is the name of the test passed to BeforeTest. Suites run by parallel.Run call BeforeTest
+	// on a new instance for each test, so the name is known to the instance running the test.
+	testName       string
+	suiteStartTime time.Time
+	testStartTime  time.Time
+	events         *logs.EventWatcher
+	// suiteSteps and testSteps are where steps of the suite and of the current test are saved.
+	suiteSteps *stepsDump
+	testSteps  *stepsDump
+}
+
+// stepsDump is where steps are saved. Steps of cleanups are added to it when cleanups finish.
+type stepsDump struct {
+	suiteName string
+	testName  string
+	// steps are steps saved before cleanups.
+	steps []logs.Step
+}
+
+// BeforeTest remembers the start time of the test to limit collected logs and starts watching events.
+func (s *Suite) BeforeTest(_, testName string) {
+	s.testName, s.testStartTime = testName, time.Now()
+	s.events = logs.WatchEvents()
+	var t = s.T()
+	var suite, test = testNames(s.testName, t)
+	progress().WithFields(suiteFields(suite, test)).Info("test started")
+	s.testSteps = nil
+	onCleanupFinished(t, func(duration time.Duration) {
+		finishCleanup(t, suite, test, duration, s.testSteps)
+	})
 }
 
-// AfterTest stores logs after each test in the suite.
+// AfterTest stores logs, steps and the event timeline after each test in the suite.
 func (s *Suite) AfterTest(suiteName, testName string) {
+	var suite, test = testNames(testName, s.T())
+	var fields = suiteFields(suite, test)
+	fields["duration"] = time.Since(s.testStartTime)
 	if s.T().Failed() {
-		logs.ClusterDump(suiteName, testName)
+		progress().WithFields(fields).Error("test failed")
+	} else {
+		progress().WithFields(fields).Info("test finished")
+	}
+	// Note: cleanups registered by the test run after AfterTest
+	startCleanup(s.T())
+	progress().WithFields(suiteFields(suite, test)).Info("cleanup started")
+
+	events := s.events.Stop()
+	s.events = nil
+	s.testSteps = &stepsDump{suiteName: suiteName, testName: testName}
+	if logs.ShouldCollect(s.T().Failed()) {
+		steps := takeSteps(s.T())
+		logs.SaveSteps(suiteName, testName, logs.Result{Failed: s.T().Failed()}, steps)
+		s.testSteps.steps = steps
+		logs.SaveTimeline(suiteName, testName, events, steps)
+		logs.ClusterDump(suiteName, testName, logs.WithSince(s.testStartTime), logs.WithNamespaces(logs.StepNamespaces(steps)...), collectorsOf(suite))
 	}
 }
 
 // TearDownSuite stores logs from containers that spawned during SuiteSetup.
 func (s *Suite) TearDownSuite() {
+	var fields = suiteFields(s.T().Name(), "")
+	fields["duration"] = time.Since(s.suiteStartTime)
+	progress().WithFields(fields).Info("suite finished")
+	startCleanup(s.T())
+	progress().WithFields(suiteFields(s.T().Name(), "")).Info("cleanup started")
+
+	logs.Wait()
+	if logs.ShouldCollect(s.T().Failed()) {
+		s.suiteDump()
+	}
+}
+
+// HandleSetupSuiteFailure stores logs from containers that spawned during SuiteSetup when the setup of the suite or its parents fails.
+// Note: it is called only for suites run by parallel.Run. testify suite.Run has no hook for failures of SetupSuite,
+// so suites run by it don't get a dump when the setup fails.
+func (s *Suite) HandleSetupSuiteFailure() {
+	var fields = suiteFields(s.T().Name(), "")
+	fields["duration"] = time.Since(s.suiteStartTime)
+	progress().WithFields(fields).Error("suite setup failed")
+
+	if logs.ShouldCollect(true) {
+		s.suiteDump()
+	}
+}
+
+func (s *Suite) suiteDump() {
+	s.suiteSteps.steps = append(s.suiteSteps.steps, takeSteps(s.T())...)
+	logs.SaveSteps(s.suiteSteps.suiteName, s.suiteSteps.testName, logs.Result{Failed: s.T().Failed()}, s.suiteSteps.steps)
+	logs.SuiteDump(s.T().Name(), collectorsOf(s.T().Name()))
+	logs.WriteReport()
+}
+
+// finishCleanup logs the end of the cleanup of the test or the suite and saves steps of the cleanup next to steps saved
+// before it. Steps are dropped if logs are not collected. dump is nil if the test has not finished.
+// The report is rewritten after the cleanup of the suite, so it has cleanup steps of the suite and its tests.
+func finishCleanup(t *testing.T, suite, test string, duration time.Duration, dump *stepsDump) {
+	defer forgetStepIndex(t)
+
+	var fields = suiteFields(suite, test)
+	fields["duration"] = duration
+	progress().WithFields(fields).Info("cleanup finished")
+
+	if dump == nil || !logs.ShouldCollect(t.Failed()) {
+		dropSteps(t)
+		return
+	}
+	logs.SaveSteps(dump.suiteName, dump.testName, logs.Result{Failed: t.Failed()}, append(dump.steps, takeSteps(t)...))
+	if test == "" {
+		logs.WriteReport()
+	}
 }
 
 // SetupSuite runs all extensions
 func (s *Suite) SetupSuite() {
+	// Note: the generated SetupSuite of the running suite calls this method, so its file tells the dir of the suite
+	var suiteDir string
+	if _, file, _, ok := runtime.Caller(1); ok && filepath.Base(file) == prefetch.GeneratedSuiteFile {
+		suiteDir = filepath.Dir(file)
+	}
+
+	s.suiteStartTime = time.Now()
+	var t = s.T()
+	progress().WithFields(suiteFields(t.Name(), "")).Info("suite started")
+	s.suiteSteps = &stepsDump{suiteName: t.Name(), testName: logs.SuiteDir}
+	onCleanupFinished(t, func(duration time.Duration) {
+		finishCleanup(t, t.Name(), "", duration, s.suiteSteps)
+	})
+
 	repo := "networkservicemesh/deployments-k8s"
 	version := sha[:8]
 
@@ -66,15 +186,35 @@ func (s *Suite) SetupSuite() {
 	s.checkout.SetupSuite()
 
 	// prefetch
-	s.prefetch.SourcesURLs = []string{
+	s.prefetch.CommonSourcesURLs = []string{
 		// Note: use urls for local image files.
 		// For example:
 		//    "file://my-debug-images-for-prefetch.yaml"
 		//    "file://deployments-k8s/apps/"
 		fmt.Sprintf("https://raw.githubusercontent.com/%v/%v/external-images.yaml", repo, version),
-		fmt.Sprintf("https://api.github.com/repos/%v/contents/apps?ref=%v", repo, version),
 	}
 
+	s.prefetch.SourcesURLs = []string{fmt.Sprintf("https://api.github.com/repos/%v/contents/apps?ref=%v", repo, version)}
+
+	// Note: resources applied by suites are rendered from the local checkout, so prefetch matches images that tests deploy
+	root := moduleRoot()
+	s.prefetch.Checkout = prefetch.Checkout{
+		Root:          root,
+		RepositoryURL: "https://github.com/" + repo,
+		Dir:           filepath.Join(root, s.checkout.Dir, path.Base(repo)),
+	}
+	s.prefetch.SuiteDir = suiteDir
+
 	s.prefetch.SetT(s.T())
 	s.prefetch.SetupSuite()
 }
+
+// moduleRoot returns the root of the module the same way shell.Runner resolves relative dirs of runners.
+func moduleRoot() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	root, _ := prefetch.ModuleRoot(wd)
+	return root
+}
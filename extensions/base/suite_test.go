@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/integration-tests/extensions/logs"
+)
+
+// TestMain sets up logs before tests, logs are configured once per process.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "artifacts-")
+	if err != nil {
+		panic(err)
+	}
+	_ = os.Setenv("LOGS_ARTIFACTS_DIR", dir)
+	_ = os.Setenv("LOGS_MODE", "always")
+
+	var code = m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func Test_FinishCleanup_ShouldSaveStepsOfCleanup(t *testing.T) {
+	var dir = t.TempDir()
+	var s = new(Suite)
+	t.Run("TestA", func(t *testing.T) {
+		s.SetT(t)
+		onCleanupFinished(t, func(duration time.Duration) {
+			finishCleanup(t, "Suite", "TestA", duration, s.testSteps)
+		})
+		var r = s.Runner(dir)
+		t.Cleanup(func() { r.Run("echo cleanup") })
+		r.Run("echo test")
+
+		startCleanup(t)
+		s.testSteps = &stepsDump{suiteName: "Suite", testName: "TestA", steps: takeSteps(t)}
+		logs.SaveSteps("Suite", "TestA", logs.Result{}, s.testSteps.steps)
+	})
+
+	steps, err := logs.ReadSteps(filepath.Join(os.Getenv("LOGS_ARTIFACTS_DIR"), logs.StepsDir, "Suite", "TestA"))
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	require.Equal(t, "test", steps[0].Stdout)
+	require.Equal(t, 1, steps[1].Index)
+	require.Equal(t, "cleanup", steps[1].Stdout)
+}
@@ -0,0 +1,453 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package capture records traffic of NSM pods while connectivity steps of tests run.
+package capture
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/integration-tests/extensions/logs"
+)
+
+// captureContainer is a name of the ephemeral container running tcpdump in pods without it.
+// Ephemeral containers can't be removed from pods, so the container is created once per pod and reused by next captures.
+const captureContainer = "capture"
+
+// Config is env config for packet capture.
+type Config struct {
+	Enabled            bool          `default:"false" desc:"Boolean variable which enables packet capture around connectivity steps"`
+	Steps              string        `default:"(ping)|(curl)|(wget)|(iperf)|(nc )" desc:"Regex of commands of connectivity steps"`
+	Duration           time.Duration `default:"30s" desc:"Max duration of a single capture"`
+	StartTimeout       time.Duration `default:"1m" desc:"Max time to find pods of the step and to start tcpdump in them before the step runs" split_words:"true"`
+	Image              string        `default:"nicolaka/netshoot:v0.13" desc:"Image of the ephemeral container running tcpdump in pods without it"`
+	Interface          string        `default:"nsm-1" desc:"Interface captured in pods of the step. Pods without it are captured on all interfaces"`
+	Endpoints          string        `default:"^nse-" desc:"Regex of names of endpoint pods captured in namespaces of the step"`
+	ForwarderNamespace string        `default:"nsm-system" desc:"Namespace of forwarders" split_words:"true"`
+	ForwarderSelector  string        `default:"app in (forwarder-vpp,forwarder-ovs)" desc:"Label selector of forwarder pods" split_words:"true"`
+	ForwarderFilter    string        `default:"udp port 4789 or udp port 51820" desc:"tcpdump filter of vxlan and wireguard tunnels in forwarders" split_words:"true"`
+}
+
+var (
+	once         sync.Once
+	config       Config
+	stepsRegex   *regexp.Regexp
+	nseRegex     *regexp.Regexp
+	segmentRegex = regexp.MustCompile(`\n|;|&&|\|\||\||\$\(|\)`)
+	// kubectl runs kubectl and writes its output into the stdout and the stderr. The stderr can be nil.
+	kubectl = func(ctx context.Context, stdout, stderr io.Writer, args ...string) error {
+		// #nosec
+		cmd := exec.CommandContext(ctx, "kubectl", args...)
+		cmd.Stdout = stdout
+		var errOut strings.Builder
+		cmd.Stderr = &errOut
+		if stderr != nil {
+			cmd.Stderr = io.MultiWriter(&errOut, stderr)
+		}
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%w: %v", err, strings.TrimSpace(errOut.String()))
+		}
+		return nil
+	}
+)
+
+func initialize() {
+	if err := envconfig.Usage("capture", &config); err != nil {
+		logrus.Fatal(err.Error())
+	}
+	if err := envconfig.Process("capture", &config); err != nil {
+		logrus.Fatal(err.Error())
+	}
+	stepsRegex = regexp.MustCompile(config.Steps)
+	nseRegex = regexp.MustCompile(config.Endpoints)
+}
+
+// Capture is a set of running tcpdump processes.
+type Capture struct {
+	dir     string
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	targets []*target
+}
+
+// Start starts capturing traffic of pods the command execs into, of endpoints in their namespaces and of forwarders
+// on nodes of these pods if it is a connectivity step. It returns when tcpdump listens in all pods or on StartTimeout.
+// Returns nil if the command is not a connectivity step.
+func Start(cmd string) *Capture {
+	once.Do(initialize)
+
+	if !config.Enabled || !stepsRegex.MatchString(cmd) {
+		return nil
+	}
+	startCtx, cancel := context.WithTimeout(context.Background(), config.StartTimeout)
+	defer cancel()
+
+	var targets = stepTargets(startCtx, cmd)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	dir, err := os.MkdirTemp("", "capture-")
+	if err != nil {
+		logrus.Errorf("An error while creating capture dir. Error: %s", err.Error())
+		return nil
+	}
+
+	var c = &Capture{dir: dir, targets: targets}
+	var captureCtx context.Context
+	captureCtx, c.cancel = context.WithTimeout(context.Background(), config.Duration+time.Minute)
+
+	for _, t := range c.targets {
+		t.ready = make(chan struct{})
+		c.wg.Add(1)
+		go func(t *target) {
+			defer c.wg.Done()
+			t.run(captureCtx, filepath.Join(dir, t.namespace+"_"+t.pod+".pcap"))
+		}(t)
+	}
+
+	for _, t := range c.targets {
+		select {
+		case <-t.ready:
+		case <-startCtx.Done():
+			logrus.Warnf("Capture of %v/%v hasn't started in %v", t.namespace, t.pod, config.StartTimeout)
+		}
+	}
+
+	return c
+}
+
+// Stop stops the capture. Pcaps are kept only if the step failed and logs of failed tests are collected according
+// to LOGS_MODE, otherwise nothing would save them. Returns paths of kept pcaps.
+func (c *Capture) Stop(failed bool) []string {
+	if c == nil {
+		return nil
+	}
+	c.cancel()
+	c.wg.Wait()
+
+	if !failed || !logs.ShouldCollect(true) {
+		_ = os.RemoveAll(c.dir)
+		return nil
+	}
+	files, _ := filepath.Glob(filepath.Join(c.dir, "*.pcap"))
+	return files
+}
+
+type target struct {
+	kubeConfig string
+	namespace  string
+	pod        string
+	// container is the ephemeral container running tcpdump or empty if the pod has tcpdump itself.
+	container string
+	iface     string
+	filter    string
+	// ready is closed when tcpdump listens or has failed.
+	ready     chan struct{}
+	readyOnce sync.Once
+}
+
+// stepTargets returns pods the command execs into, endpoints in namespaces of these pods and forwarders on nodes of all of them.
+func stepTargets(ctx context.Context, cmd string) []*target {
+	var result []*target
+	var seen = make(map[string]bool)
+	add := func(t *target) {
+		var key = t.kubeConfig + "/" + t.namespace + "/" + t.pod
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, t)
+		}
+	}
+
+	var seenNodes = make(map[string]bool)
+	addPod := func(kubeConfig, namespace string, p podNode) {
+		add(&target{kubeConfig: kubeConfig, namespace: namespace, pod: p.pod, iface: config.Interface})
+		if seenNodes[kubeConfig+"/"+p.node] {
+			return
+		}
+		seenNodes[kubeConfig+"/"+p.node] = true
+		for _, forwarder := range nodeForwarders(ctx, kubeConfig, p.node) {
+			add(&target{kubeConfig: kubeConfig, namespace: config.ForwarderNamespace, pod: forwarder, iface: "any", filter: config.ForwarderFilter})
+		}
+	}
+
+	var seenNamespaces = make(map[string]bool)
+	for _, e := range execTargets(cmd) {
+		pod, node := resolvePod(ctx, e.kubeConfig, e.namespace, e.resource)
+		if pod == "" {
+			continue
+		}
+		addPod(e.kubeConfig, e.namespace, podNode{pod: pod, node: node})
+		if seenNamespaces[e.kubeConfig+"/"+e.namespace] {
+			continue
+		}
+		seenNamespaces[e.kubeConfig+"/"+e.namespace] = true
+		for _, nse := range namespaceEndpoints(ctx, e.kubeConfig, e.namespace) {
+			addPod(e.kubeConfig, e.namespace, nse)
+		}
+	}
+
+	return result
+}
+
+type podNode struct {
+	pod  string
+	node string
+}
+
+type execTarget struct {
+	kubeConfig string
+	namespace  string
+	resource   string
+}
+
+// execTargets returns resources of kubectl exec commands of the step, e.g. pods/alpine or deployments/nse-kernel.
+// Targets passed by shell variables are skipped, their values are known only to the shell of the test.
+func execTargets(cmd string) []execTarget {
+	var result []execTarget
+	for _, segment := range segmentRegex.Split(cmd, -1) {
+		var fields = strings.Fields(segment)
+		if len(fields) == 0 || fields[0] != "kubectl" {
+			continue
+		}
+		var resource string
+		var isExec bool
+		for i := 1; i < len(fields) && fields[i] != "--"; i++ {
+			var field = fields[i]
+			switch {
+			case strings.HasPrefix(field, "-"):
+				if !strings.Contains(field, "=") && valueFlags[field] {
+					i++
+				}
+			case !isExec:
+				isExec = field == "exec"
+				if !isExec {
+					i = len(fields)
+				}
+			case resource == "":
+				resource = field
+			}
+		}
+		if resource == "" || strings.Contains(resource, "$") {
+			continue
+		}
+		var namespace = logs.CommandNamespace(segment)
+		if namespace == "" {
+			namespace = "default"
+		}
+		result = append(result, execTarget{kubeConfig: logs.CommandKubeConfig(segment), namespace: namespace, resource: resource})
+	}
+	return result
+}
+
+// valueFlags are kubectl flags that take the value from the next argument.
+var valueFlags = map[string]bool{
+	"-n": true, "--namespace": true, "-c": true, "--container": true,
+	"--kubeconfig": true, "--context": true, "--cluster": true, "--user": true, "--pod-running-timeout": true,
+}
+
+// resolvePod returns the name and the node of the pod the kubectl exec of the resource runs in.
+func resolvePod(ctx context.Context, kubeConfig, namespace, resource string) (pod, node string) {
+	var kind, name = "pods", resource
+	if i := strings.Index(resource, "/"); i >= 0 {
+		kind, name = resource[:i], resource[i+1:]
+	}
+
+	var out string
+	var err error
+	switch kind {
+	case "po", "pod", "pods":
+		out, err = kubectlOutput(ctx, kubeConfig, "get", "pod", name, "-n", namespace, "-o", "jsonpath={.metadata.name} {.spec.nodeName}")
+	default:
+		var labels string
+		labels, err = kubectlOutput(ctx, kubeConfig, "get", resource, "-n", namespace, "-o", "jsonpath={.spec.selector.matchLabels}")
+		if err == nil {
+			out, err = kubectlOutput(ctx, kubeConfig, "get", "pods", "-n", namespace, "-l", selector(labels),
+				"--field-selector=status.phase=Running", "-o", "jsonpath={.items[0].metadata.name} {.items[0].spec.nodeName}")
+		}
+	}
+	if err != nil {
+		logrus.Warnf("An error while getting the pod of %v/%v for capture. Error: %s", namespace, resource, err.Error())
+		return "", ""
+	}
+	var fields = strings.Fields(out)
+	if len(fields) != 2 {
+		return "", ""
+	}
+	return fields[0], fields[1]
+}
+
+// selector converts matchLabels in JSON to the label selector.
+func selector(labels string) string {
+	var m map[string]string
+	if err := json.Unmarshal([]byte(labels), &m); err != nil {
+		return ""
+	}
+	var result []string
+	for k, v := range m {
+		result = append(result, k+"="+v)
+	}
+	sort.Strings(result)
+	return strings.Join(result, ",")
+}
+
+// namespaceEndpoints returns running pods of the namespace matching Endpoints.
+func namespaceEndpoints(ctx context.Context, kubeConfig, namespace string) []podNode {
+	out, err := kubectlOutput(ctx, kubeConfig, "get", "pods", "-n", namespace, "--field-selector=status.phase=Running",
+		"-o", `jsonpath={range .items[*]}{.metadata.name} {.spec.nodeName}{"\n"}{end}`)
+	if err != nil {
+		logrus.Warnf("An error while getting endpoints of %v for capture. Error: %s", namespace, err.Error())
+		return nil
+	}
+	var result []podNode
+	for _, line := range strings.Split(out, "\n") {
+		var fields = strings.Fields(line)
+		if len(fields) == 2 && nseRegex.MatchString(fields[0]) {
+			result = append(result, podNode{pod: fields[0], node: fields[1]})
+		}
+	}
+	return result
+}
+
+func nodeForwarders(ctx context.Context, kubeConfig, node string) []string {
+	out, err := kubectlOutput(ctx, kubeConfig, "get", "pods", "-n", config.ForwarderNamespace, "-l", config.ForwarderSelector,
+		"--field-selector=spec.nodeName="+node+",status.phase=Running", "-o", "jsonpath={.items[*].metadata.name}")
+	if err != nil {
+		logrus.Warnf("An error while getting forwarders of %v for capture. Error: %s", node, err.Error())
+		return nil
+	}
+	return strings.Fields(out)
+}
+
+// run runs tcpdump in the pod and writes the pcap into the file.
+func (t *target) run(ctx context.Context, file string) {
+	defer t.setReady()
+
+	if err := t.prepare(ctx); err != nil {
+		logrus.Warnf("Capture of %v/%v has failed. Error: %s", t.namespace, t.pod, err.Error())
+		return
+	}
+
+	f, err := os.Create(filepath.Clean(file))
+	if err != nil {
+		logrus.Errorf("An error while creating %v. Error: %s", file, err.Error())
+		return
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err = kubectl(ctx, f, &listeningWriter{t: t}, t.args()...); err != nil && ctx.Err() == nil {
+		logrus.Warnf("Capture of %v/%v has failed. Error: %s", t.namespace, t.pod, err.Error())
+	}
+}
+
+// prepare chooses the container running tcpdump. tcpdump runs in place if the pod has it. Otherwise it runs
+// in the ephemeral container which is created if the pod has no running one yet.
+// Containers of a pod share the network namespace, so the ephemeral container sees interfaces of the pod.
+func (t *target) prepare(ctx context.Context) error {
+	if kubectl(ctx, io.Discard, nil, t.kubectlArgs("exec", "pod/"+t.pod, "-n", t.namespace, "--", "sh", "-c", "command -v tcpdump")...) == nil {
+		return nil
+	}
+	t.container = captureContainer
+	if t.containerRunning(ctx) {
+		return nil
+	}
+
+	var err = kubectl(ctx, io.Discard, nil, t.kubectlArgs("debug", "pod/"+t.pod, "-n", t.namespace, "--quiet", "--profile=netadmin",
+		"--image="+config.Image, "--container="+captureContainer, "--", "sleep", "infinity")...)
+	if err != nil {
+		return err
+	}
+	for !t.containerRunning(ctx) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+	return nil
+}
+
+func (t *target) containerRunning(ctx context.Context) bool {
+	var out strings.Builder
+	var err = kubectl(ctx, &out, nil, t.kubectlArgs("get", "pod", t.pod, "-n", t.namespace, "-o",
+		fmt.Sprintf(`jsonpath={.status.ephemeralContainerStatuses[?(@.name=="%v")].state.running.startedAt}`, captureContainer))...)
+	return err == nil && strings.TrimSpace(out.String()) != ""
+}
+
+func (t *target) args() []string {
+	var script = fmt.Sprintf(`i=%v; [ -e /sys/class/net/$i ] || i=any; exec timeout %v tcpdump -i $i -U -w - %v`,
+		t.iface, int(config.Duration.Seconds()), t.filter)
+
+	var args = []string{"exec", "pod/" + t.pod, "-n", t.namespace}
+	if t.container != "" {
+		args = append(args, "-c", t.container)
+	}
+	return t.kubectlArgs(append(args, "--", "sh", "-c", strings.TrimSpace(script))...)
+}
+
+func (t *target) kubectlArgs(args ...string) []string {
+	if t.kubeConfig == "" {
+		return args
+	}
+	return append([]string{"--kubeconfig", t.kubeConfig}, args...)
+}
+
+func kubectlOutput(ctx context.Context, kubeConfig string, args ...string) (string, error) {
+	if kubeConfig != "" {
+		args = append([]string{"--kubeconfig", kubeConfig}, args...)
+	}
+	var out strings.Builder
+	var err = kubectl(ctx, &out, nil, args...)
+	return out.String(), err
+}
+
+// listeningWriter reads the stderr of tcpdump and marks the target ready when tcpdump reports that it listens.
+type listeningWriter struct {
+	t         *target
+	out       []byte
+	listening bool
+}
+
+func (w *listeningWriter) Write(p []byte) (int, error) {
+	if !w.listening {
+		w.out = append(w.out, p...)
+		if strings.Contains(string(w.out), "listening on") {
+			w.listening = true
+			w.t.setReady()
+		}
+	}
+	return len(p), nil
+}
+
+func (t *target) setReady() {
+	t.readyOnce.Do(func() { close(t.ready) })
+}
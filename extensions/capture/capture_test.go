@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCluster is a stand-in for kubectl with the pod alpine and the endpoint nse-kernel which have no tcpdump
+// and forwarders on their nodes which have it.
+type fakeCluster struct {
+	mu        sync.Mutex
+	debugged  map[string]int
+	tcpdumped []string
+}
+
+func (c *fakeCluster) kubectl(_ context.Context, stdout, stderr io.Writer, args ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var cmd = strings.Join(args, " ")
+	switch {
+	case cmd == "get deployments/alpine -n ns-a -o jsonpath={.spec.selector.matchLabels}":
+		_, _ = io.WriteString(stdout, `{"app":"alpine"}`)
+	case strings.HasPrefix(cmd, "get pods -n ns-a -l app=alpine "):
+		_, _ = io.WriteString(stdout, "alpine-7d9f node-1")
+	case strings.HasPrefix(cmd, "get pods -n ns-a --field-selector=status.phase=Running "):
+		_, _ = io.WriteString(stdout, "alpine-7d9f node-1\nnse-kernel-5f node-2\n")
+	case strings.HasPrefix(cmd, "get pods -n nsm-system -l forwarder --field-selector=spec.nodeName=node-1,"):
+		_, _ = io.WriteString(stdout, "forwarder-vpp-x")
+	case strings.HasPrefix(cmd, "get pods -n nsm-system -l forwarder --field-selector=spec.nodeName=node-2,"):
+		_, _ = io.WriteString(stdout, "forwarder-vpp-y")
+	case strings.HasSuffix(cmd, "command -v tcpdump"):
+		if !strings.Contains(cmd, "forwarder") {
+			return errors.New("exit status 1")
+		}
+	case strings.HasPrefix(cmd, "debug pod/"):
+		c.debugged[args[1]]++
+	case strings.HasPrefix(cmd, "get pod "):
+		if c.debugged["pod/"+args[2]] > 0 {
+			_, _ = io.WriteString(stdout, "2026-01-02T10:00:00Z")
+		}
+	case strings.Contains(cmd, "tcpdump"):
+		c.tcpdumped = append(c.tcpdumped, cmd)
+		_, _ = io.WriteString(stderr, "tcpdump: listening on nsm-1, link-type EN10MB (Ethernet)\n")
+		_, _ = io.WriteString(stdout, "pcap of "+args[1])
+	default:
+		return errors.New("unexpected command: " + cmd)
+	}
+	return nil
+}
+
+func useFakeCluster(t *testing.T) *fakeCluster {
+	once.Do(func() {})
+	var c = &fakeCluster{debugged: make(map[string]int)}
+	var prevKubectl, prevConfig = kubectl, config
+	t.Cleanup(func() { kubectl, config = prevKubectl, prevConfig })
+
+	kubectl = c.kubectl
+	config = Config{
+		Enabled:            true,
+		Duration:           10 * time.Second,
+		StartTimeout:       10 * time.Second,
+		Image:              "netshoot",
+		Interface:          "nsm-1",
+		ForwarderNamespace: "nsm-system",
+		ForwarderSelector:  "forwarder",
+	}
+	stepsRegex = regexp.MustCompile("ping")
+	nseRegex = regexp.MustCompile("^nse-")
+	return c
+}
+
+func Test_ExecTargets_ShouldFindPodsOfStep(t *testing.T) {
+	require.Equal(t, []execTarget{
+		{kubeConfig: "/cluster1", namespace: "ns-a", resource: "pods/alpine"},
+		{namespace: "ns-b", resource: "deployments/nse-kernel"},
+	}, execTargets(`kubectl --kubeconfig=/cluster1 exec -it pods/alpine -n ns-a -- ping -c 4 172.16.1.100
+kubectl get pods -n ns-b && kubectl exec -n ns-b -c nse deployments/nse-kernel -- ping -c 4 172.16.1.101
+kubectl exec ${NSC} -n ns-b -- ping -c 4 172.16.1.100`))
+}
+
+func Test_Capture_ShouldRecordPodsOfStepEndpointsAndTheirForwarders(t *testing.T) {
+	var c = useFakeCluster(t)
+	const cmd = "kubectl exec deployments/alpine -n ns-a -- ping -c 4 172.16.1.100"
+
+	require.Nil(t, Start("kubectl apply -k ."))
+
+	var files = Start(cmd).Stop(true)
+	require.Len(t, files, 4)
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Contains(t, []string{"pcap of pod/alpine-7d9f", "pcap of pod/nse-kernel-5f", "pcap of pod/forwarder-vpp-x", "pcap of pod/forwarder-vpp-y"}, string(b))
+	}
+	require.NoError(t, os.RemoveAll(filepath.Dir(files[0])))
+	require.Len(t, c.tcpdumped, 4)
+	for _, cmd := range c.tcpdumped {
+		require.Equal(t, !strings.Contains(cmd, "forwarder"), strings.Contains(cmd, "-c "+captureContainer+" "), cmd)
+	}
+
+	var capture = Start(cmd)
+	var dir = capture.dir
+	require.Nil(t, capture.Stop(false))
+	_, err := os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+
+	require.Equal(t, map[string]int{"pod/alpine-7d9f": 1, "pod/nse-kernel-5f": 1}, c.debugged, "the ephemeral container must be reused")
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main compares cluster dumps of a passing and a failing run of the same test.
+//
+// Usage:
+//
+//	go run ./extensions/logs/cmd/dumpdiff [-suite <suite> -test <test>] <passing dir> <failing dir>
+//
+// Dirs are either cluster dumps of the test or artifacts dirs, in this case -suite and -test are required.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/networkservicemesh/integration-tests/extensions/logs"
+)
+
+func main() {
+	suiteName := flag.String("suite", "", "name of the suite in artifacts dirs")
+	testName := flag.String("test", "", "name of the test in artifacts dirs")
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		_, _ = fmt.Fprintln(os.Stderr, "usage: dumpdiff [-suite <suite> -test <test>] <passing dir> <failing dir>")
+		os.Exit(2)
+	}
+
+	d, err := logs.CompareDumps(flag.Arg(0), flag.Arg(1), *suiteName, *testName)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	fmt.Print(d.String())
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	stateDir        = "state"
+	spireServerExec = "exec -n spire spire-server-0 -c spire-server -- /opt/spire/bin/spire-server"
+)
+
+// Collector gathers additional state of the cluster, for example custom resources or data-plane state.
+type Collector func(c *Cluster)
+
+// Cluster is a cluster that is being dumped. It is passed to collectors.
+type Cluster struct {
+	KubeConfig string
+	Dir        string
+	run        func(cmd string) (stdout string, exitCode int, err error)
+}
+
+// Kubectl returns kubectl command for the cluster.
+func (c *Cluster) Kubectl(args string) string {
+	return fmt.Sprintf("kubectl --kubeconfig %v %v", c.KubeConfig, args)
+}
+
+// Save runs the command and stores its output into the file. file is relative to the dir of the collector.
+func (c *Cluster) Save(file, cmd string) {
+	var p = filepath.Join(c.Dir, file)
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		logrus.Errorf("An error while collecting %v. Error: %s", file, err.Error())
+		return
+	}
+
+	_, exitCode, err := c.run(fmt.Sprintf("%v > %v 2>&1", cmd, p))
+	if err != nil {
+		logrus.Errorf("An error while collecting %v. Error: %s", file, err.Error())
+		return
+	}
+	if exitCode != 0 {
+		logrus.Errorf("An error while collecting %v. Exit Code: %v", file, exitCode)
+	}
+}
+
+// Pods returns names of pods matched by the label selector in the namespace.
+func (c *Cluster) Pods(namespace, selector string) []string {
+	stdout, exitCode, err := c.run(c.Kubectl(fmt.Sprintf(`get pods -n %v -l %v -o go-template='{{range .items}}{{ .metadata.name }} {{end}}'`, namespace, selector)))
+	if err != nil || exitCode != 0 {
+		return nil
+	}
+	return strings.Fields(stdout)
+}
+
+var (
+	collectorsMu sync.Mutex
+	collectors   = map[string]Collector{
+		"nsm":         collectNSM,
+		"spire":       collectSpire,
+		"vpp":         collectVPP,
+		"ovs":         collectOVS,
+		"interdomain": collectInterdomain,
+	}
+)
+
+// defaultCollectors are collectors that run for every dump unless LOGS_COLLECTORS is set.
+var defaultCollectors = []string{"nsm"}
+
+// RegisterCollector adds the collector to the registry. Registered collectors are used if a suite enables them
+// with WithCollectors or they are listed in LOGS_COLLECTORS.
+func RegisterCollector(name string, collector Collector) {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+
+	collectors[name] = collector
+}
+
+// enabledCollectors returns collectors of the dump. The dump is shared by requests of the batch, so it runs collectors
+// of all of them. LOGS_COLLECTORS overrides collectors of requests if it is set.
+func enabledCollectors(batch []*dumpRequest) []string {
+	if len(config.Collectors) > 0 {
+		return config.Collectors
+	}
+	var result = append([]string(nil), defaultCollectors...)
+	for _, r := range batch {
+		result = append(result, r.collectors...)
+	}
+	return result
+}
+
+// runCollectors runs collectors by names. Each collector stores its files into state/<name> of the dump dir.
+func runCollectors(c *Cluster, names []string) {
+	var enabled = make(map[string]Collector)
+	collectorsMu.Lock()
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			enabled[name] = collectors[name]
+		}
+	}
+	collectorsMu.Unlock()
+
+	var sorted = make([]string, 0, len(enabled))
+	for name := range enabled {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		if enabled[name] == nil {
+			logrus.Errorf("Collector %v is not registered", name)
+			continue
+		}
+		enabled[name](&Cluster{
+			KubeConfig: c.KubeConfig,
+			Dir:        filepath.Join(c.Dir, stateDir, name),
+			run:        c.run,
+		})
+	}
+}
+
+func collectNSM(c *Cluster) {
+	c.Save("networkservices.yaml", c.Kubectl("get networkservices.networkservicemesh.io -A -o yaml"))
+	c.Save("networkserviceendpoints.yaml", c.Kubectl("get networkserviceendpoints.networkservicemesh.io -A -o yaml"))
+}
+
+func collectSpire(c *Cluster) {
+	c.Save("entries.txt", c.Kubectl(spireServerExec+" entry show"))
+	c.Save("bundle.pem", c.Kubectl(spireServerExec+" bundle show"))
+	c.Save("clusterspiffeids.yaml", c.Kubectl("get clusterspiffeids.spire.spiffe.io -o yaml"))
+}
+
+func collectInterdomain(c *Cluster) {
+	c.Save("federation.txt", c.Kubectl(spireServerExec+" federation list"))
+	c.Save("federated-bundles.txt", c.Kubectl(spireServerExec+" bundle list"))
+	c.Save("clusterfederatedtrustdomains.yaml", c.Kubectl("get clusterfederatedtrustdomains.spire.spiffe.io -o yaml"))
+}
+
+func collectVPP(c *Cluster) {
+	for _, pod := range c.Pods("nsm-system", "app=forwarder-vpp") {
+		var exec = fmt.Sprintf("exec -n nsm-system %v -- vppctl ", pod)
+		c.Save(filepath.Join(pod, "show-int.txt"), c.Kubectl(exec+"show int"))
+		c.Save(filepath.Join(pod, "show-ip-fib.txt"), c.Kubectl(exec+"show ip fib"))
+		c.Save(filepath.Join(pod, "show-memif.txt"), c.Kubectl(exec+"show memif"))
+	}
+}
+
+func collectOVS(c *Cluster) {
+	for _, pod := range c.Pods("nsm-system", "app=forwarder-ovs") {
+		var exec = fmt.Sprintf("exec -n nsm-system %v -- ", pod)
+		c.Save(filepath.Join(pod, "ovs-vsctl-show.txt"), c.Kubectl(exec+"ovs-vsctl show"))
+		c.Save(filepath.Join(pod, "ovs-ofctl-dump-flows.txt"),
+			c.Kubectl(exec+`sh -c 'for br in $(ovs-vsctl list-br); do echo "bridge: $br"; ovs-ofctl dump-flows $br; done'`))
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const trustBundle = "-----BEGIN CERTIFICATE-----\nMIIBzDCCAVKgAwIBAgIQ\n-----END CERTIFICATE-----\n"
+
+var saveRegex = regexp.MustCompile(`^(.*) > (\S+) 2>&1$`)
+
+// fakeCluster answers commands of collectors with canned outputs and records them.
+type fakeCluster struct {
+	mu       sync.Mutex
+	commands []string
+	outputs  map[string]string
+}
+
+func (f *fakeCluster) run(cmd string) (string, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = append(f.commands, cmd)
+
+	var file string
+	if m := saveRegex.FindStringSubmatch(cmd); m != nil {
+		cmd, file = m[1], m[2]
+	}
+	var output string
+	for suffix, o := range f.outputs {
+		if strings.HasSuffix(cmd, suffix) {
+			output = o
+		}
+	}
+	if file != "" {
+		return "", 0, os.WriteFile(file, []byte(output), 0o600)
+	}
+	return output, 0, nil
+}
+
+func Test_RunCollectors_ShouldRunRegisteredCollectors(t *testing.T) {
+	RegisterCollector("test-custom", func(c *Cluster) {
+		c.Save("custom.txt", c.Kubectl("get custom"))
+	})
+
+	var dir = t.TempDir()
+	var f = &fakeCluster{outputs: map[string]string{
+		"-l app=forwarder-vpp -o go-template='{{range .items}}{{ .metadata.name }} {{end}}'": "forwarder-vpp-a forwarder-vpp-b",
+		"vppctl show int": "local0",
+		"get custom":      "custom state",
+	}}
+	runCollectors(&Cluster{KubeConfig: "/kubeconfig", Dir: dir, run: f.run}, []string{"vpp", " test-custom", "unknown", ""})
+
+	for _, p := range []string{
+		"state/test-custom/custom.txt",
+		"state/vpp/forwarder-vpp-a/show-int.txt",
+		"state/vpp/forwarder-vpp-b/show-ip-fib.txt",
+	} {
+		require.FileExists(t, filepath.Join(dir, p))
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "state/vpp/forwarder-vpp-b/show-int.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "local0", string(b))
+	require.Contains(t, f.commands, "kubectl --kubeconfig /kubeconfig exec -n nsm-system forwarder-vpp-a -- vppctl show memif > "+
+		filepath.Join(dir, "state/vpp/forwarder-vpp-a/show-memif.txt")+" 2>&1")
+	require.NoDirExists(t, filepath.Join(dir, "state/nsm"))
+}
+
+func Test_EnabledCollectors_ShouldCombineSuitesUnlessOverridden(t *testing.T) {
+	var batch = []*dumpRequest{{name: "Suite/TestA", collectors: []string{"vpp"}}, {name: "Suite/TestB", collectors: []string{"spire"}}}
+
+	var prev = config.Collectors
+	t.Cleanup(func() { config.Collectors = prev })
+
+	config.Collectors = nil
+	require.Equal(t, []string{"nsm", "vpp", "spire"}, enabledCollectors(batch))
+
+	config.Collectors = []string{"ovs"}
+	require.Equal(t, []string{"ovs"}, enabledCollectors(batch))
+}
+
+func Test_CollectSpire_ShouldKeepTrustBundleAfterRedaction(t *testing.T) {
+	var dir = t.TempDir()
+	var f = &fakeCluster{outputs: map[string]string{
+		"spire-server bundle show": trustBundle,
+		"spire-server entry show":  "Found 1 entry",
+	}}
+	runCollectors(&Cluster{KubeConfig: "/kubeconfig", Dir: dir, run: f.run}, []string{"spire"})
+
+	r, err := newRedactor(&Config{RedactionEnabled: true})
+	require.NoError(t, err)
+	var target = t.TempDir()
+	require.NoError(t, r.RedactDir(dir, target))
+
+	b, err := os.ReadFile(filepath.Join(target, stateDir, "spire", "bundle.pem"))
+	require.NoError(t, err)
+	require.Equal(t, trustBundle, string(b))
+	require.FileExists(t, filepath.Join(target, stateDir, "spire", "entries.txt"))
+	require.FileExists(t, filepath.Join(target, stateDir, "spire", "clusterspiffeids.yaml"))
+}
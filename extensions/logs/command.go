@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	namespaceRegex       = regexp.MustCompile(`(?:^|\s)(?:-n|--namespace)(?:=|\s+)(\S+)`)
+	kubeConfigRegex      = regexp.MustCompile(`(?:^|\s)--kubeconfig(?:=|\s+)(\S+)`)
+	createNamespaceRegex = regexp.MustCompile(`kubectl\s[^\n]*?\bcreate\s+(?:ns|namespace)\s+(\S+)`)
+)
+
+// CommandNamespace returns the namespace of the kubectl command. Arguments of the command executed in the pod are ignored.
+func CommandNamespace(cmd string) string {
+	return flagOf(namespaceRegex, cmd)
+}
+
+// CommandKubeConfig returns the kubeconfig passed to the kubectl command with expanded env variables.
+// Returns empty string if the command uses the default one.
+func CommandKubeConfig(cmd string) string {
+	return os.ExpandEnv(flagOf(kubeConfigRegex, cmd))
+}
+
+// StepNamespaces returns namespaces used by steps: namespaces passed to kubectl commands and namespaces created by them.
+// Namespaces passed by shell variables are skipped.
+func StepNamespaces(steps []Step) []string {
+	var result []string
+	var seen = make(map[string]bool)
+	add := func(name string) {
+		name = strings.Trim(name, `"'`)
+		if name != "" && !strings.Contains(name, "$") && !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	for i := range steps {
+		for _, line := range strings.Split(steps[i].Command, "\n") {
+			if j := strings.Index(line, " -- "); j >= 0 {
+				line = line[:j]
+			}
+			for _, m := range namespaceRegex.FindAllStringSubmatch(line, -1) {
+				add(m[1])
+			}
+			for _, m := range createNamespaceRegex.FindAllStringSubmatch(line, -1) {
+				add(m[1])
+			}
+		}
+	}
+	return result
+}
+
+func flagOf(r *regexp.Regexp, cmd string) string {
+	if i := strings.Index(cmd, " -- "); i >= 0 {
+		cmd = cmd[:i]
+	}
+	m := r.FindStringSubmatch(cmd)
+	if m == nil {
+		return ""
+	}
+	return strings.Trim(m[1], `"'`)
+}
+
+// ClusterName returns the name of the cluster dir of the kubeconfig, e.g. cluster0 for KUBECONFIG1.
+// Returns empty string for unknown kubeconfigs of multi-cluster runs.
+func ClusterName(kubeConfig string) string {
+	once.Do(func() { initialize() })
+
+	if len(kubeConfigs) == 1 {
+		return "cluster0"
+	}
+	for i := range kubeConfigs {
+		if kubeConfigs[i] == kubeConfig {
+			return fmt.Sprintf("cluster%v", i)
+		}
+	}
+	return ""
+}
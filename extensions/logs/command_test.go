@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Parse_ShouldFindNamespaceAndKubeConfig(t *testing.T) {
+	t.Setenv("KUBECONFIG2", "/cluster2")
+
+	for _, tc := range []struct {
+		cmd, namespace, kubeConfig string
+	}{
+		{cmd: "kubectl exec pods/alpine -n ns-kernel2ethernet2kernel -- ping -c 4 172.16.1.100", namespace: "ns-kernel2ethernet2kernel"},
+		{cmd: "kubectl --kubeconfig=$KUBECONFIG2 exec deployments/nse-kernel --namespace=ns-a -- ping -n 4 172.16.1.101", namespace: "ns-a", kubeConfig: "/cluster2"},
+		{cmd: `kubectl exec pods/alpine --kubeconfig "$KUBECONFIG2" -- ping -n 4 172.16.1.101`, kubeConfig: "/cluster2"},
+	} {
+		require.Equal(t, tc.namespace, CommandNamespace(tc.cmd), tc.cmd)
+		require.Equal(t, tc.kubeConfig, CommandKubeConfig(tc.cmd), tc.cmd)
+	}
+}
+
+func Test_StepNamespaces_ShouldFindNamespacesOfSteps(t *testing.T) {
+	require.Equal(t, []string{"ns-kernel2kernel", "nsm-system", "ns-remote"}, StepNamespaces([]Step{
+		{Command: "kubectl create ns ns-kernel2kernel"},
+		{Command: "kubectl apply -k .\nkubectl wait --for=condition=ready --timeout=1m pod -l app=alpine -n ns-kernel2kernel"},
+		{Command: "kubectl exec pods/alpine -n ns-kernel2kernel -- ping -n 4 172.16.1.100"},
+		{Command: "kubectl get pods --namespace=nsm-system\nkubectl --kubeconfig=$KUBECONFIG2 create namespace ns-remote"},
+		{Command: "kubectl delete ns $NAMESPACE"},
+	}))
+}
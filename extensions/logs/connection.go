@@ -0,0 +1,226 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	nsmTimeLayout = "Jan _2 15:04:05.000"
+	podLogsFile   = "logs.txt"
+	maxLineSize   = 16 << 20
+)
+
+var (
+	ansiRegex           = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	containerStartRegex = regexp.MustCompile(`^==== START logs for container (\S+) of pod (\S+) ====`)
+)
+
+// ConnectionEvent is a single log line that mentions the traced connection.
+type ConnectionEvent struct {
+	Time      time.Time
+	Cluster   string
+	Namespace string
+	Pod       string
+	Container string
+	Message   string
+}
+
+// Source returns a name of the pod or docker container that has produced the event.
+func (e *ConnectionEvent) Source() string {
+	if e.Namespace == "" {
+		return fmt.Sprintf("%v/%v", e.Cluster, e.Pod)
+	}
+	return fmt.Sprintf("%v/%v/%v", e.Cluster, e.Namespace, e.Pod)
+}
+
+// ConnectionTimeline contains all events of the one NSM connection ordered by time.
+type ConnectionTimeline struct {
+	ID     string
+	Events []ConnectionEvent
+}
+
+// Path returns sources in order the connection request has passed them. For example: nsc -> nsmgr -> forwarder -> nse.
+func (t *ConnectionTimeline) Path() []string {
+	var result []string
+	var visited = make(map[string]bool)
+
+	for i := range t.Events {
+		var source = t.Events[i].Source()
+		if !visited[source] {
+			visited[source] = true
+			result = append(result, source)
+		}
+	}
+
+	return result
+}
+
+// String formats the timeline in a human readable form.
+func (t *ConnectionTimeline) String() string {
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "connection: %v\n", t.ID)
+	_, _ = fmt.Fprintf(&sb, "path: %v\n", strings.Join(t.Path(), " -> "))
+
+	for i := range t.Events {
+		var e = &t.Events[i]
+		var ts = "-"
+		if !e.Time.IsZero() {
+			ts = e.Time.Format(nsmTimeLayout)
+		}
+		_, _ = fmt.Fprintf(&sb, "%v [%v] %v\n", ts, e.Source(), e.Message)
+	}
+
+	return sb.String()
+}
+
+// TraceConnection rebuilds the request path of the connection from the logs collected by ClusterDump for the test.
+// Logs from all clusters and external containers are used, so it works for interdomain suites as well.
+func TraceConnection(suiteName, testName, connectionID string) (*ConnectionTimeline, error) {
+	once.Do(func() { initialize() })
+
+	dirs, err := filepath.Glob(filepath.Join(config.ArtifactsDir, "cluster*", suiteName, testName))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = os.Stat(filepath.Join(config.ArtifactsDir, ExternalDir, suiteName, testName)); err == nil {
+		dirs = append(dirs, filepath.Join(config.ArtifactsDir, ExternalDir, suiteName, testName))
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no logs found for %v/%v in %v", suiteName, testName, config.ArtifactsDir)
+	}
+
+	var result = &ConnectionTimeline{ID: connectionID}
+	var idRegex = connectionIDRegex(connectionID)
+
+	for _, dir := range dirs {
+		var cluster = filepath.Base(filepath.Dir(filepath.Dir(dir)))
+		events, traceErr := traceDir(cluster, dir, idRegex)
+		if traceErr != nil {
+			return nil, traceErr
+		}
+		result.Events = append(result.Events, events...)
+	}
+
+	sortEvents(result.Events)
+
+	return result, nil
+}
+
+func sortEvents(events []ConnectionEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+}
+
+func connectionIDRegex(connectionID string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^\w-])` + regexp.QuoteMeta(connectionID) + `($|[^\w-])`)
+}
+
+func traceDir(cluster, dir string, idRegex *regexp.Regexp) ([]ConnectionEvent, error) {
+	var result []ConnectionEvent
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		var source = ConnectionEvent{Cluster: cluster}
+
+		switch {
+		case d.Name() == podLogsFile:
+			rel, relErr := filepath.Rel(dir, filepath.Dir(p))
+			if relErr != nil {
+				return relErr
+			}
+			namespace, pod := filepath.Split(rel)
+			source.Namespace, source.Pod = strings.TrimSuffix(namespace, string(filepath.Separator)), pod
+		case filepath.Dir(p) == dir && strings.HasSuffix(d.Name(), ".log"):
+			source.Pod = strings.TrimSuffix(d.Name(), ".log")
+		default:
+			return nil
+		}
+
+		events, traceErr := traceFile(p, &source, idRegex)
+		result = append(result, events...)
+
+		return traceErr
+	})
+
+	return result, err
+}
+
+func traceFile(p string, source *ConnectionEvent, idRegex *regexp.Regexp) ([]ConnectionEvent, error) {
+	f, err := os.Open(filepath.Clean(p))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var result []ConnectionEvent
+	var lastTime time.Time
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	for scanner.Scan() {
+		var line = ansiRegex.ReplaceAllString(scanner.Text(), "")
+
+		if match := containerStartRegex.FindStringSubmatch(line); match != nil {
+			source.Container = match[1]
+			lastTime = time.Time{}
+			continue
+		}
+		if t, ok := parseTime(line); ok {
+			lastTime = t
+		}
+		if !idRegex.MatchString(line) {
+			continue
+		}
+
+		var event = *source
+		event.Time = lastTime
+		event.Message = strings.TrimSpace(line)
+		result = append(result, event)
+	}
+
+	return result, scanner.Err()
+}
+
+// parseTime parses timestamp of NSM log line. Lines without timestamp belong to the previous one.
+func parseTime(line string) (time.Time, bool) {
+	if len(line) < len(nsmTimeLayout) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(nsmTimeLayout, line[:len(nsmTimeLayout)])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeLog(t *testing.T, p, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o750))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+}
+
+func Test_TraceDir_ShouldOrderEventsAcrossClusters(t *testing.T) {
+	var root = t.TempDir()
+	var cluster0 = filepath.Join(root, "cluster0", "suite", "test")
+	var cluster1 = filepath.Join(root, "cluster1", "suite", "test")
+
+	writeLog(t, filepath.Join(cluster0, "nsm-system", "nsmgr-a", podLogsFile),
+		"==== START logs for container nsmgr of pod nsm-system/nsmgr-a ====\n"+
+			"Jan  2 10:00:01.000 [INFO] [id:alpine-0] request\n"+
+			"Jan  2 10:00:01.500 [INFO] [id:alpine-0-1] another connection\n"+
+			"Jan  2 10:00:04.000 [INFO] [id:alpine-0] response\n")
+	writeLog(t, filepath.Join(cluster1, "ns-a", "nse-b", podLogsFile),
+		"Jan  2 10:00:02.000 [INFO] [id:alpine-0] request\n"+
+			"    continuation of alpine-0\n")
+	writeLog(t, filepath.Join(cluster0, "nsc-docker.log"),
+		"Jan  2 10:00:00.000 [INFO] [id:alpine-0] request\n")
+
+	var timeline = &ConnectionTimeline{ID: "alpine-0"}
+	var idRegex = connectionIDRegex(timeline.ID)
+
+	for cluster, dir := range map[string]string{"cluster0": cluster0, "cluster1": cluster1} {
+		events, err := traceDir(cluster, dir, idRegex)
+		require.NoError(t, err)
+		timeline.Events = append(timeline.Events, events...)
+	}
+	sortEvents(timeline.Events)
+
+	require.Len(t, timeline.Events, 5)
+	require.Equal(t, "nsmgr", timeline.Events[1].Container)
+	require.Equal(t, "continuation of alpine-0", timeline.Events[3].Message)
+	require.Equal(t, []string{
+		"cluster0/nsc-docker",
+		"cluster0/nsm-system/nsmgr-a",
+		"cluster1/ns-a/nse-b",
+	}, timeline.Path())
+}
@@ -0,0 +1,448 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	podsFile         = "pods.json"
+	maxSignatureSize = 300
+)
+
+// Note: kubernetes generates pod name suffixes from this alphabet, so words like "agent" are not taken for hashes
+const podHashAlphabet = `[bcdfghjklmnpqrstvwxz2456789]`
+
+var normalizers = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}(\.\d+)?`), "<time>"},
+	{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), "<uid>"},
+	{regexp.MustCompile(`\b([a-z0-9][a-z0-9-]*?)-` + podHashAlphabet + `{8,10}-` + podHashAlphabet + `{5}\b`), "$1-<hash>"},
+	{regexp.MustCompile(`\b([a-z0-9][a-z0-9-]*?)-` + podHashAlphabet + `{5}\b`), "$1-<hash>"},
+	{regexp.MustCompile(`\b[0-9a-f]{16,}\b`), "<id>"},
+	{regexp.MustCompile(`\b\d+(\.\d+)?(ns|µs|us|ms|s|m|h)\b`), "<duration>"},
+}
+
+var errorLineRegex = regexp.MustCompile(`(?i)\b(erro|error|fata|fatal|pani|panic)\b`)
+
+// volatileMetadata are metadata fields of resources that differ between runs.
+var volatileMetadata = []string{"uid", "resourceVersion", "creationTimestamp", "generation", "managedFields", "selfLink"}
+
+// Normalize removes timestamps, UIDs, durations and hashes of pod names from the text.
+func Normalize(s string) string {
+	s = ansiRegex.ReplaceAllString(s, "")
+	for _, n := range normalizers {
+		s = n.pattern.ReplaceAllString(s, n.replacement)
+	}
+	return s
+}
+
+// DumpDiff is a difference between the dump of a passing run and the dump of a failing one.
+type DumpDiff struct {
+	Clusters []*ClusterDiff
+}
+
+// ClusterDiff is a difference between dumps of the cluster.
+type ClusterDiff struct {
+	Cluster string
+	// MissingPods are pods of the passing run that are absent in the failing one.
+	MissingPods []string
+	// ExtraPods are pods of the failing run that are absent in the passing one.
+	ExtraPods []string
+	Restarts  []string
+	Images    []string
+	// Resources are changed lines of NSM custom resources. Lines start with - for passing and + for failing runs.
+	Resources []string
+	// Errors are error signatures of the failing run that are absent in the passing one.
+	Errors []string
+}
+
+// Empty returns true if dumps don't differ.
+func (d *ClusterDiff) Empty() bool {
+	return len(d.MissingPods)+len(d.ExtraPods)+len(d.Restarts)+len(d.Images)+len(d.Resources)+len(d.Errors) == 0
+}
+
+// CompareDumps compares dumps of the same test. Dirs are either cluster dumps of the test (dirs with the manifest)
+// or roots of artifacts dirs, in this case suiteName and testName select dumps of all clusters.
+func CompareDumps(passing, failing, suiteName, testName string) (*DumpDiff, error) {
+	passingDirs, err := dumpDirs(passing, suiteName, testName)
+	if err != nil {
+		return nil, err
+	}
+	failingDirs, err := dumpDirs(failing, suiteName, testName)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters = make(map[string]bool)
+	for c := range passingDirs {
+		clusters[c] = true
+	}
+	for c := range failingDirs {
+		clusters[c] = true
+	}
+	var names []string
+	for c := range clusters {
+		names = append(names, c)
+	}
+	sort.Strings(names)
+
+	var result = new(DumpDiff)
+	for _, c := range names {
+		if passingDirs[c] == "" || failingDirs[c] == "" {
+			result.Clusters = append(result.Clusters, &ClusterDiff{Cluster: c, Errors: []string{"the cluster is dumped in one run only"}})
+			continue
+		}
+		d, compareErr := compareCluster(passingDirs[c], failingDirs[c])
+		if compareErr != nil {
+			return nil, compareErr
+		}
+		d.Cluster = c
+		result.Clusters = append(result.Clusters, d)
+	}
+	return result, nil
+}
+
+// String returns a human readable report.
+func (d *DumpDiff) String() string {
+	var sb strings.Builder
+	for _, c := range d.Clusters {
+		_, _ = fmt.Fprintf(&sb, "=== %v\n", c.Cluster)
+		if c.Empty() {
+			_, _ = sb.WriteString("no differences\n")
+			continue
+		}
+		for _, section := range []struct {
+			title string
+			lines []string
+		}{
+			{"Pods missing in the failing run", c.MissingPods},
+			{"Pods absent in the passing run", c.ExtraPods},
+			{"Restarts", c.Restarts},
+			{"Images", c.Images},
+			{"NSM resources", c.Resources},
+			{"New errors", c.Errors},
+		} {
+			if len(section.lines) == 0 {
+				continue
+			}
+			_, _ = fmt.Fprintf(&sb, "%v:\n", section.title)
+			for _, l := range section.lines {
+				_, _ = fmt.Fprintf(&sb, "  %v\n", l)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// dumpDirs returns dump dirs by cluster names.
+func dumpDirs(dir, suiteName, testName string) (map[string]string, error) {
+	var result = make(map[string]string)
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+		manifest, readErr := ReadManifest(dir)
+		if readErr != nil {
+			return nil, readErr
+		}
+		result[manifest.Cluster] = dir
+		return result, nil
+	}
+
+	dirs, err := filepath.Glob(filepath.Join(dir, "cluster*", suiteName, testName))
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dirs {
+		result[filepath.Base(filepath.Dir(filepath.Dir(d)))] = d
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no dumps found in %v", dir)
+	}
+	return result, nil
+}
+
+func compareCluster(passing, failing string) (*ClusterDiff, error) {
+	var result = new(ClusterDiff)
+
+	passingPods, err := readPods(passing)
+	if err != nil {
+		return nil, err
+	}
+	failingPods, err := readPods(failing)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range sortedKeys(passingPods) {
+		p, ok := failingPods[name]
+		if !ok {
+			result.MissingPods = append(result.MissingPods, name)
+			continue
+		}
+		for _, c := range sortedKeys(p.images) {
+			if image, found := passingPods[name].images[c]; found && image != p.images[c] {
+				result.Images = append(result.Images, fmt.Sprintf("%v/%v: %v -> %v", name, c, image, p.images[c]))
+			}
+		}
+		for _, c := range sortedKeys(p.restarts) {
+			if before := passingPods[name].restarts[c]; p.restarts[c] != before {
+				result.Restarts = append(result.Restarts, fmt.Sprintf("%v/%v: %v -> %v", name, c, before, p.restarts[c]))
+			}
+		}
+	}
+	for _, name := range sortedKeys(failingPods) {
+		if _, ok := passingPods[name]; !ok {
+			result.ExtraPods = append(result.ExtraPods, name)
+			for _, c := range sortedKeys(failingPods[name].restarts) {
+				if restarts := failingPods[name].restarts[c]; restarts > 0 {
+					result.Restarts = append(result.Restarts, fmt.Sprintf("%v/%v: %v", name, c, restarts))
+				}
+			}
+		}
+	}
+
+	if result.Resources, err = compareResources(passing, failing); err != nil {
+		return nil, err
+	}
+
+	passingErrors := errorSignatures(passing)
+	failingErrors := errorSignatures(failing)
+	for _, s := range sortedKeys(failingErrors) {
+		if _, ok := passingErrors[s]; !ok {
+			result.Errors = append(result.Errors, fmt.Sprintf("%v (%v times, %v)", s, failingErrors[s].count, failingErrors[s].source))
+		}
+	}
+
+	return result, nil
+}
+
+type podSummary struct {
+	images   map[string]string
+	restarts map[string]int
+}
+
+// readPods reads pods of all namespaces of the dump. Pods are keyed by namespace and normalized name.
+func readPods(dir string) (map[string]*podSummary, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*", podsFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var result = make(map[string]*podSummary)
+	for _, f := range files {
+		b, readErr := os.ReadFile(filepath.Clean(f))
+		if readErr != nil {
+			return nil, readErr
+		}
+		var list struct {
+			Items []struct {
+				Metadata struct {
+					Name      string `json:"name"`
+					Namespace string `json:"namespace"`
+				} `json:"metadata"`
+				Spec struct {
+					Containers []struct {
+						Name  string `json:"name"`
+						Image string `json:"image"`
+					} `json:"containers"`
+				} `json:"spec"`
+				Status struct {
+					ContainerStatuses []struct {
+						Name         string `json:"name"`
+						RestartCount int    `json:"restartCount"`
+					} `json:"containerStatuses"`
+				} `json:"status"`
+			} `json:"items"`
+		}
+		if err = json.Unmarshal(b, &list); err != nil {
+			return nil, fmt.Errorf("%v: %w", f, err)
+		}
+		for i := range list.Items {
+			var item = &list.Items[i]
+			var name = item.Metadata.Namespace + "/" + Normalize(item.Metadata.Name)
+			var p = result[name]
+			if p == nil {
+				p = &podSummary{images: make(map[string]string), restarts: make(map[string]int)}
+				result[name] = p
+			}
+			for _, c := range item.Spec.Containers {
+				p.images[c.Name] = c.Image
+			}
+			// Note: replicas of the same deployment share the key, so restarts are summed
+			for _, c := range item.Status.ContainerStatuses {
+				p.restarts[c.Name] += c.RestartCount
+			}
+		}
+	}
+	return result, nil
+}
+
+// compareResources compares NSM custom resources collected by the nsm collector.
+func compareResources(passing, failing string) ([]string, error) {
+	var files = make(map[string]bool)
+	for _, dir := range []string{passing, failing} {
+		found, err := filepath.Glob(filepath.Join(dir, stateDir, "nsm", "*.yaml"))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range found {
+			files[filepath.Base(f)] = true
+		}
+	}
+
+	var result []string
+	for _, f := range sortedKeys(files) {
+		before := readResources(filepath.Join(passing, stateDir, "nsm", f))
+		after := readResources(filepath.Join(failing, stateDir, "nsm", f))
+		for _, name := range sortedKeys(before) {
+			if _, ok := after[name]; !ok {
+				result = append(result, fmt.Sprintf("- %v", name))
+			}
+		}
+		for _, name := range sortedKeys(after) {
+			if _, ok := before[name]; !ok {
+				result = append(result, fmt.Sprintf("+ %v", name))
+				continue
+			}
+			for _, l := range diffLines(before[name], after[name]) {
+				result = append(result, fmt.Sprintf("%v: %v", name, l))
+			}
+		}
+	}
+	return result, nil
+}
+
+// readResources reads items of the kubectl list in yaml format. Items are keyed by kind, namespace and normalized name.
+func readResources(p string) map[string]string {
+	var result = make(map[string]string)
+
+	b, err := os.ReadFile(filepath.Clean(p))
+	if err != nil {
+		return result
+	}
+	var list struct {
+		Items []map[string]interface{} `yaml:"items"`
+	}
+	if err = yaml.Unmarshal(b, &list); err != nil {
+		return result
+	}
+
+	for _, item := range list.Items {
+		metadata, _ := item["metadata"].(map[string]interface{})
+		for _, field := range volatileMetadata {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		}
+		out, marshalErr := yaml.Marshal(item)
+		if marshalErr != nil {
+			continue
+		}
+		var name = Normalize(fmt.Sprintf("%v/%v/%v", item["kind"], metadata["namespace"], metadata["name"]))
+		result[name] = Normalize(string(out))
+	}
+	return result
+}
+
+// diffLines returns lines that present in only one of texts.
+func diffLines(before, after string) []string {
+	var result []string
+	var beforeLines = strings.Split(before, "\n")
+	var afterLines = strings.Split(after, "\n")
+	var inBefore = make(map[string]bool)
+	var inAfter = make(map[string]bool)
+	for _, l := range beforeLines {
+		inBefore[l] = true
+	}
+	for _, l := range afterLines {
+		inAfter[l] = true
+	}
+	for _, l := range beforeLines {
+		if !inAfter[l] {
+			result = append(result, "- "+strings.TrimSpace(l))
+		}
+	}
+	for _, l := range afterLines {
+		if !inBefore[l] {
+			result = append(result, "+ "+strings.TrimSpace(l))
+		}
+	}
+	return result
+}
+
+type errorSignature struct {
+	count  int
+	source string
+}
+
+// errorSignatures returns normalized error lines of pod and container logs of the dump.
+func errorSignatures(dir string) map[string]*errorSignature {
+	var result = make(map[string]*errorSignature)
+	_ = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || (info.Name() != podLogsFile && filepath.Ext(p) != ".log") {
+			return nil
+		}
+		f, openErr := os.Open(filepath.Clean(p))
+		if openErr != nil {
+			return nil
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		var source, _ = filepath.Rel(dir, filepath.Dir(p))
+		var scanner = bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		for scanner.Scan() {
+			var line = scanner.Text()
+			if !errorLineRegex.MatchString(line) {
+				continue
+			}
+			var s = strings.TrimSpace(Normalize(line))
+			if len(s) > maxSignatureSize {
+				s = s[:maxSignatureSize]
+			}
+			if result[s] == nil {
+				result[s] = &errorSignature{source: Normalize(source)}
+			}
+			result[s].count++
+		}
+		return nil
+	})
+	return result
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	var result = make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
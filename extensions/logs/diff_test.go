@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const dumpPods = `{"items": [
+  {"metadata": {"name": "nsmgr-%[1]v", "namespace": "nsm-system"},
+   "spec": {"containers": [{"name": "nsmgr", "image": "ghcr.io/networkservicemesh/cmd-nsmgr:%[2]v"}]},
+   "status": {"containerStatuses": [{"name": "nsmgr", "restartCount": %[3]v}]}},
+  {"metadata": {"name": "nse-kernel-%[4]v", "namespace": "nsm-system"},
+   "spec": {"containers": [{"name": "nse", "image": "ghcr.io/networkservicemesh/cmd-nse-icmp-responder:v1.14.0"}]},
+   "status": {"containerStatuses": [{"name": "nse", "restartCount": 0}]}}
+]}`
+
+const dumpEndpoints = `apiVersion: v1
+items:
+- apiVersion: networkservicemesh.io/v1
+  kind: NetworkServiceEndpoint
+  metadata:
+    name: nse-kernel-%[1]v
+    namespace: nsm-system
+    uid: %[2]v
+    resourceVersion: "%[3]v"
+  spec:
+    expirationTime: "%[4]v"
+    network_service_names:
+    - %[5]v
+kind: List
+`
+
+func writeDump(t *testing.T, dir, podHash, tag string, restarts int, service, log string) {
+	writeLog(t, filepath.Join(dir, ManifestFile), `{"cluster": "cluster0"}`)
+	writeLog(t, filepath.Join(dir, "nsm-system", podsFile), fmt.Sprintf(dumpPods, podHash, tag, restarts, "6d8f4b7c9x-"+podHash))
+	writeLog(t, filepath.Join(dir, stateDir, "nsm", "networkserviceendpoints.yaml"),
+		fmt.Sprintf(dumpEndpoints, "6d8f4b7c9x-"+podHash, "c7b7c0a4-6c3e-4c4b-9b7a-"+podHash+"0000000", len(log), "2026-01-02T10:00:00Z", service))
+	writeLog(t, filepath.Join(dir, "nsm-system", "nsmgr-"+podHash, podLogsFile), log)
+}
+
+func Test_CompareDumps_ShouldIgnoreVolatileFields(t *testing.T) {
+	var passing = filepath.Join(t.TempDir(), "passing")
+	var failing = filepath.Join(t.TempDir(), "failing")
+
+	writeDump(t, passing, "x2k4b", "v1.14.0", 0, "kernel2ethernet2kernel",
+		"Jan  2 10:00:01.000 [INFO] started\nJan  2 10:00:02.000 [ERRO] [id:nsc-1] dial timeout after 15s\n")
+	writeDump(t, failing, "q7z9m", "v1.14.0", 0, "kernel2ethernet2kernel",
+		"Jan  3 11:00:01.000 [INFO] started\nJan  3 11:00:05.000 [ERRO] [id:nsc-1] dial timeout after 20s\n")
+
+	d, err := CompareDumps(passing, failing, "", "")
+	require.NoError(t, err)
+	require.Len(t, d.Clusters, 1)
+	require.True(t, d.Clusters[0].Empty(), d.String())
+}
+
+func Test_CompareDumps_ShouldReportDifferences(t *testing.T) {
+	var root = t.TempDir()
+	var passing = filepath.Join(root, "passing", "cluster0", "suite", "test")
+	var failing = filepath.Join(root, "failing", "cluster0", "suite", "test")
+
+	writeDump(t, passing, "x2k4b", "v1.14.0", 0, "kernel2ethernet2kernel", "Jan  2 10:00:01.000 [INFO] started\n")
+	writeDump(t, failing, "q7z9m", "v1.14.1", 2, "kernel2vxlan2kernel",
+		"Jan  3 11:00:01.000 [INFO] started\nJan  3 11:00:05.000 [ERRO] no route to host\n")
+	writeLog(t, filepath.Join(failing, "ns-a", podsFile), `{"items": [{"metadata": {"name": "alpine", "namespace": "ns-a"}}]}`)
+
+	d, err := CompareDumps(filepath.Join(root, "passing"), filepath.Join(root, "failing"), "suite", "test")
+	require.NoError(t, err)
+	require.Len(t, d.Clusters, 1)
+
+	var c = d.Clusters[0]
+	require.Equal(t, []string{"ns-a/alpine"}, c.ExtraPods)
+	require.Empty(t, c.MissingPods)
+	require.Equal(t, []string{"nsm-system/nsmgr-<hash>/nsmgr: 0 -> 2"}, c.Restarts)
+	require.Equal(t, []string{"nsm-system/nsmgr-<hash>/nsmgr: ghcr.io/networkservicemesh/cmd-nsmgr:v1.14.0 -> ghcr.io/networkservicemesh/cmd-nsmgr:v1.14.1"}, c.Images)
+	require.Equal(t, []string{
+		"NetworkServiceEndpoint/nsm-system/nse-kernel-<hash>: - - kernel2ethernet2kernel",
+		"NetworkServiceEndpoint/nsm-system/nse-kernel-<hash>: + - kernel2vxlan2kernel",
+	}, c.Resources)
+	require.Equal(t, []string{"<time> [ERRO] no route to host (1 times, nsm-system/nsmgr-<hash>)"}, c.Errors)
+}
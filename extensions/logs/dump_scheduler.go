@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+type dumpRequest struct {
+	name       string
+	since      time.Time
+	namespaces *regexp.Regexp
+	collectors []string
+	// testNamespaces are namespaces of the test. Empty for suite dumps.
+	testNamespaces []string
+	done           chan struct{}
+}
+
+// dumpScheduler runs dumps one by one. Requests that arrive while a dump is running are served by the next single dump,
+// which is shared between all of them. So every request gets artifacts taken after it has been scheduled.
+// Only requests for the same namespaces share a dump.
+type dumpScheduler struct {
+	queue   chan *dumpRequest
+	pending int32
+	body    func(batch []*dumpRequest)
+}
+
+// newDumpScheduler creates a scheduler with the bounded queue. body is called with all requests of the batch.
+func newDumpScheduler(queueSize int, body func(batch []*dumpRequest)) *dumpScheduler {
+	var s = &dumpScheduler{
+		queue: make(chan *dumpRequest, queueSize),
+		body:  body,
+	}
+	go s.serve()
+	return s
+}
+
+// Dump schedules the dump of namespaces and waits for its completion. It blocks while the queue is full.
+func (s *dumpScheduler) Dump(r *dumpRequest) {
+	r.done = make(chan struct{})
+
+	atomic.AddInt32(&s.pending, 1)
+	s.queue <- r
+	<-r.done
+}
+
+// Wait waits for all scheduled dumps.
+func (s *dumpScheduler) Wait() {
+	for atomic.LoadInt32(&s.pending) != 0 {
+		<-time.After(time.Millisecond * 25)
+	}
+}
+
+func (s *dumpScheduler) serve() {
+	var next *dumpRequest
+
+	for {
+		if next == nil {
+			var ok bool
+			if next, ok = <-s.queue; !ok {
+				return
+			}
+		}
+
+		var batch = []*dumpRequest{next}
+		next = nil
+
+	drain:
+		for {
+			select {
+			case r := <-s.queue:
+				if r.namespaces != batch[0].namespaces {
+					next = r
+					break drain
+				}
+				batch = append(batch, r)
+			default:
+				break drain
+			}
+		}
+
+		s.body(batch)
+
+		for _, b := range batch {
+			atomic.AddInt32(&s.pending, -1)
+			close(b.done)
+		}
+	}
+}
+
+// linkDir hard links all files of the dir into the target dir. Files are copied if hard links are not supported.
+func linkDir(dir, target string) error {
+	files, err := listArtifacts(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		var p = filepath.Join(target, filepath.FromSlash(f.rel))
+		if mkdirErr := os.MkdirAll(filepath.Dir(p), 0o750); mkdirErr != nil {
+			return mkdirErr
+		}
+		if os.Link(f.path, p) == nil {
+			continue
+		}
+		if copyErr := copyFile(f.path, p); copyErr != nil {
+			return copyErr
+		}
+	}
+
+	return nil
+}
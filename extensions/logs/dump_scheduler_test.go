@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DumpScheduler_ShouldServeEveryRequest(t *testing.T) {
+	var mu sync.Mutex
+	var served = make(map[string]int)
+	var dumps int
+	var started = make(chan struct{})
+	var release = make(chan struct{})
+
+	var s = newDumpScheduler(4, func(batch []*dumpRequest) {
+		if dumps == 0 {
+			close(started)
+			<-release
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		dumps++
+		for _, r := range batch {
+			served[r.name]++
+		}
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.Dump(&dumpRequest{name: "suite/Test0"})
+	}()
+	<-started
+
+	for i := 1; i <= 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.Dump(&dumpRequest{name: fmt.Sprintf("suite/Test%v", i)})
+		}(i)
+	}
+	require.Eventually(t, func() bool { return len(s.queue) == 3 }, time.Second, time.Millisecond*10)
+	close(release)
+
+	wg.Wait()
+	s.Wait()
+
+	require.Equal(t, 2, dumps)
+	require.Equal(t, map[string]int{"suite/Test0": 1, "suite/Test1": 1, "suite/Test2": 1, "suite/Test3": 1}, served)
+}
+
+func Test_LinkDir_ShouldShareSnapshot(t *testing.T) {
+	var dir = t.TempDir()
+	var target = filepath.Join(t.TempDir(), "Test1")
+	writeLog(t, filepath.Join(dir, "ns-a", "nsc", podLogsFile), "nsc logs")
+
+	require.NoError(t, linkDir(dir, target))
+
+	b, err := os.ReadFile(filepath.Join(target, "ns-a", "nsc", podLogsFile))
+	require.NoError(t, err)
+	require.Equal(t, "nsc logs", string(b))
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// TimelineFile is a name of the file with the event timeline of the test.
+	TimelineFile = "timeline.json"
+	// TimelineTextFile is a name of the human readable event timeline of the test.
+	TimelineTextFile = "timeline.txt"
+	noStep           = -1
+)
+
+// kubeEvent is a Kubernetes event in the format of kubectl get events -o json.
+type kubeEvent struct {
+	Metadata struct {
+		UID       string `json:"uid"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Type           string    `json:"type"`
+	Reason         string    `json:"reason"`
+	Message        string    `json:"message"`
+	Count          int       `json:"count"`
+	FirstTimestamp time.Time `json:"firstTimestamp"`
+	LastTimestamp  time.Time `json:"lastTimestamp"`
+	EventTime      time.Time `json:"eventTime"`
+	InvolvedObject struct {
+		Kind      string `json:"kind"`
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+	} `json:"involvedObject"`
+}
+
+func (e *kubeEvent) toEvent() Event {
+	var result = Event{
+		Time:    e.LastTimestamp,
+		Type:    e.Type,
+		Reason:  e.Reason,
+		Object:  fmt.Sprintf("%v/%v/%v", e.InvolvedObject.Kind, e.InvolvedObject.Namespace, e.InvolvedObject.Name),
+		Message: e.Message,
+		Count:   e.Count,
+	}
+	for _, t := range []time.Time{e.EventTime, e.FirstTimestamp} {
+		if result.Time.IsZero() {
+			result.Time = t
+		}
+	}
+	return result
+}
+
+// TimelineEvent is an event tied to the step of the test.
+type TimelineEvent struct {
+	Event
+	// Step is an index of the step that was running when the event happened or noStep if no step has been started yet.
+	Step int
+}
+
+// EventWatcher watches Kubernetes events of allowed namespaces on all clusters.
+type EventWatcher struct {
+	since      time.Time
+	namespaces *regexp.Regexp
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	mu         sync.Mutex
+	seen       map[string]bool
+	events     []Event
+}
+
+// WatchEvents starts watching events on every kubeconfig. Returns nil if logs are never collected.
+func WatchEvents() *EventWatcher {
+	once.Do(func() { initialize() })
+
+	if !mode.shouldCollect(true) {
+		return nil
+	}
+
+	// Note: event timestamps have a precision of seconds
+	var w = &EventWatcher{since: time.Now().Truncate(time.Second), namespaces: matchRegex, seen: make(map[string]bool)}
+	var watchCtx context.Context
+	watchCtx, w.cancel = context.WithCancel(ctx)
+
+	for i := range kubeConfigs {
+		var cluster = fmt.Sprintf("cluster%v", i)
+		// #nosec
+		cmd := exec.CommandContext(watchCtx, "kubectl", "--kubeconfig", kubeConfigs[i], "get", "events", "--all-namespaces", "--watch", "-o", "json")
+		cmd.Stderr = io.Discard
+		stdout, err := cmd.StdoutPipe()
+		if err == nil {
+			err = cmd.Start()
+		}
+		if err != nil {
+			logrus.Errorf("An error while watching events of %v. Error: %s", cluster, err.Error())
+			continue
+		}
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			w.read(cluster, stdout)
+			_ = cmd.Wait()
+		}()
+	}
+
+	return w
+}
+
+// Stop stops watching and returns collected events in chronological order.
+func (w *EventWatcher) Stop() []Event {
+	if w == nil {
+		return nil
+	}
+	w.cancel()
+	w.wg.Wait()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	sort.SliceStable(w.events, func(i, j int) bool { return w.events[i].Time.Before(w.events[j].Time) })
+	return w.events
+}
+
+// read decodes the stream of events of the cluster and keeps events of allowed namespaces that happened since the start.
+func (w *EventWatcher) read(cluster string, r io.Reader) {
+	var decoder = json.NewDecoder(r)
+	for {
+		var e kubeEvent
+		if err := decoder.Decode(&e); err != nil {
+			return
+		}
+		var ns = e.Metadata.Namespace
+		if ns == "" {
+			ns = e.InvolvedObject.Namespace
+		}
+		if w.namespaces != nil && !w.namespaces.MatchString(ns) {
+			continue
+		}
+		var event = e.toEvent()
+		if event.Time.Before(w.since) {
+			continue
+		}
+		event.Cluster = cluster
+
+		// Note: the watch relists events after reconnects, updates of the event have a new count
+		var key = fmt.Sprintf("%v/%v/%v/%v", cluster, e.Metadata.UID, e.Count, event.Time.UnixNano())
+		w.mu.Lock()
+		if !w.seen[key] {
+			w.seen[key] = true
+			w.events = append(w.events, event)
+		}
+		w.mu.Unlock()
+	}
+}
+
+// Timeline ties events to steps. An event belongs to the last step started before it, so events that happen
+// right after the step finishes, e.g. after kubectl apply, are tied to the step that caused them.
+// Event timestamps are truncated to seconds, so ambiguous events are tied to the earlier step.
+func Timeline(events []Event, steps []Step) []TimelineEvent {
+	var result = make([]TimelineEvent, 0, len(events))
+	for i := range events {
+		var step = noStep
+		for j := range steps {
+			if steps[j].Start.After(events[i].Time) {
+				break
+			}
+			step = steps[j].Index
+		}
+		result = append(result, TimelineEvent{Event: events[i], Step: step})
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Time.Before(result[j].Time) })
+	return result
+}
+
+// SaveTimeline stores the merged event timeline of the test into the artifacts dir next to steps of the test.
+func SaveTimeline(suiteName, testName string, events []Event, steps []Step) {
+	once.Do(func() { initialize() })
+
+	var name = filepath.Join(suiteName, testName)
+	var timeline = Timeline(events, steps)
+	collectArtifacts(filepath.Join(config.ArtifactsDir, StepsDir), []*dumpRequest{{name: name}}, func(dir string) {
+		b, err := json.MarshalIndent(timeline, "", "  ")
+		if err == nil {
+			err = os.WriteFile(filepath.Join(dir, TimelineFile), b, 0o600)
+		}
+		if err == nil {
+			err = os.WriteFile(filepath.Join(dir, TimelineTextFile), []byte(formatTimeline(timeline, steps)), 0o600)
+		}
+		if err != nil {
+			logrus.Errorf("An error while saving event timeline. Error: %s", err.Error())
+		}
+	})
+}
+
+// ReadTimeline reads the timeline stored by SaveTimeline from the dir.
+func ReadTimeline(dir string) ([]TimelineEvent, error) {
+	b, err := os.ReadFile(filepath.Clean(filepath.Join(dir, TimelineFile)))
+	if err != nil {
+		return nil, err
+	}
+	var result []TimelineEvent
+	if err = json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func formatTimeline(timeline []TimelineEvent, steps []Step) string {
+	var commands = make(map[int]string)
+	for i := range steps {
+		commands[steps[i].Index] = strings.SplitN(strings.TrimSpace(steps[i].Command), "\n", 2)[0]
+	}
+
+	var sb strings.Builder
+	var step = noStep
+	for i := range timeline {
+		var e = &timeline[i]
+		if e.Step != step || i == 0 {
+			step = e.Step
+			if step == noStep {
+				_, _ = sb.WriteString("=== before steps\n")
+			} else {
+				_, _ = fmt.Fprintf(&sb, "=== step %v: %v\n", step, commands[step])
+			}
+		}
+		_, _ = fmt.Fprintf(&sb, "%v %v %v %v %v: %v\n", e.Time.Format(time.RFC3339), e.Cluster, e.Type, e.Reason, e.Object, e.Message)
+	}
+	return sb.String()
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const watchedEvents = `{
+  "metadata": {"uid": "1", "namespace": "kube-system"},
+  "type": "Normal", "reason": "Pulled", "count": 1,
+  "lastTimestamp": "2026-01-02T10:00:05Z",
+  "involvedObject": {"kind": "Pod", "namespace": "kube-system", "name": "coredns"}
+}
+{
+  "metadata": {"uid": "2", "namespace": "ns-a"},
+  "type": "Normal", "reason": "Scheduled", "count": 1,
+  "lastTimestamp": "2026-01-02T09:59:00Z",
+  "involvedObject": {"kind": "Pod", "namespace": "ns-a", "name": "old"}
+}
+{
+  "metadata": {"uid": "3", "namespace": "ns-a"},
+  "type": "Warning", "reason": "BackOff", "count": 1, "message": "Back-off pulling image",
+  "lastTimestamp": "2026-01-02T10:00:07Z",
+  "involvedObject": {"kind": "Pod", "namespace": "ns-a", "name": "alpine"}
+}
+{
+  "metadata": {"uid": "4", "namespace": "ns-a"},
+  "type": "Normal", "reason": "Scheduled", "count": 1,
+  "lastTimestamp": "2026-01-02T10:00:02Z",
+  "involvedObject": {"kind": "Pod", "namespace": "ns-a", "name": "alpine"}
+}
+{
+  "metadata": {"uid": "3", "namespace": "ns-a"},
+  "type": "Warning", "reason": "BackOff", "count": 1, "message": "Back-off pulling image",
+  "lastTimestamp": "2026-01-02T10:00:07Z",
+  "involvedObject": {"kind": "Pod", "namespace": "ns-a", "name": "alpine"}
+}
+`
+
+func Test_EventWatcher_ShouldBuildTimelineOfSteps(t *testing.T) {
+	var start = time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	var w = &EventWatcher{since: start, namespaces: regexp.MustCompile("^ns-"), seen: make(map[string]bool), cancel: func() {}}
+	w.read("cluster0", strings.NewReader(watchedEvents))
+
+	var events = w.Stop()
+	require.Len(t, events, 2)
+
+	var steps = []Step{
+		{Index: 0, Command: "kubectl apply -k .", Start: start.Add(time.Second), Duration: time.Second},
+		{Index: 1, Command: "kubectl wait --for=condition=ready pod -l app=alpine -n ns-a", Start: start.Add(2500 * time.Millisecond)},
+	}
+	var timeline = Timeline(events, steps)
+	require.Equal(t, "Scheduled", timeline[0].Reason)
+	require.Equal(t, 0, timeline[0].Step)
+	require.Equal(t, "BackOff", timeline[1].Reason)
+	require.Equal(t, "cluster0", timeline[1].Cluster)
+	require.Equal(t, 1, timeline[1].Step)
+
+	require.Equal(t, noStep, Timeline(events, nil)[0].Step)
+	require.Contains(t, formatTimeline(timeline, steps), "=== step 1: kubectl wait")
+}
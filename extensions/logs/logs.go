@@ -1,6 +1,6 @@
 // Copyright (c) 2021-2022 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2023-2024 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -38,14 +38,19 @@ import (
 )
 
 var (
-	once                       sync.Once
-	config                     Config
-	ctx                        context.Context
-	kubeConfigs                []string
-	matchRegex                 *regexp.Regexp
-	dockerRegex                *regexp.Regexp
-	runner                     *bash.Bash
-	clusterDumpSingleOperation *singleOperation
+	once                 sync.Once
+	config               Config
+	ctx                  context.Context
+	kubeConfigs          []string
+	matchRegex           *regexp.Regexp
+	suiteRegex           *regexp.Regexp
+	dockerRegex          *regexp.Regexp
+	runner               *bash.Bash
+	artifactsSink        Sink
+	artifactsRedactor    *redactor
+	externalRuntime      *containerRuntime
+	mode                 *collectionMode
+	clusterDumpScheduler *dumpScheduler
 )
 
 // Config is env config to setup log collecting.
@@ -55,8 +60,27 @@ type Config struct {
 	WorkerCount          int           `default:"8" desc:"Number of log collector workers" split_words:"true"`
 	MaxKubeConfigs       int           `default:"3" desc:"Number of used kubeconfigs" split_words:"true"`
 	AllowedNamespaces    string        `default:"(ns-.*)|(nsm-system)|(spire)|(observability)" desc:"Regex of allowed namespaces" split_words:"true"`
+	SuiteNamespaces      string        `default:"(nsm-system)|(spire)|(observability)" desc:"Regex of namespaces collected on suite level" split_words:"true"`
 	AllowedContainers    string        `default:"(nsc-.*)|(nse-.*)" desc:"Regexp of allowed docker containers" split_words:"true"`
-	LogCollectionEnabled bool          `default:"true" desc:"Boolean variable which enables log collection" split_words:"true"`
+	ContainerRuntime     string        `default:"auto" desc:"CLI of the runtime of external containers: docker, podman, nerdctl or auto" split_words:"true"`
+	LogCollectionEnabled bool          `default:"true" desc:"Boolean variable which enables log collection. Mode never is used if it is false" split_words:"true"`
+	Mode                 string        `default:"on-failure" desc:"When logs are collected: never, on-failure, always or sample:N% (N percent of passed tests and all failed ones)"`
+	DumpQueueSize        int           `default:"16" desc:"Max number of pending cluster dump requests" split_words:"true"`
+	Sink                 string        `default:"dir" desc:"Sink for storing collected artifacts: dir, tar or s3" split_words:"true"`
+	SinkDir              string        `default:"" desc:"Target directory for dir and tar sinks. Artifacts dir is used if empty" split_words:"true"`
+	SinkPrefix           string        `default:"" desc:"Per-run prefix for stored artifacts. The dir sink without its own dir adds it to the artifacts dir" split_words:"true"`
+	SinkSizeLimit        int64         `default:"0" desc:"Max size in bytes of files written into a single artifacts dir of a dump, e.g. cluster0/<suite>/<test>. Files are kept in name order. 0 means no limit" split_words:"true"`
+	S3Endpoint           string        `default:"" desc:"Endpoint of S3 compatible object store, e.g. http://127.0.0.1:9000" split_words:"true"`
+	S3Bucket             string        `default:"" desc:"Bucket for storing artifacts" split_words:"true"`
+	S3Region             string        `default:"us-east-1" desc:"Region of the object store" split_words:"true"`
+	S3AccessKey          string        `default:"" desc:"Access key of the object store" split_words:"true"`
+	S3SecretKey          string        `default:"" desc:"Secret key of the object store" split_words:"true"`
+	S3UploadTimeout      time.Duration `default:"10m" desc:"Timeout of a single upload into the object store" split_words:"true"`
+	Collectors           []string      `default:"" desc:"Comma separated list of collectors: nsm, spire, vpp, ovs, interdomain. Overrides collectors enabled by suites if set" split_words:"true"`
+	RedactionEnabled     bool          `default:"true" desc:"Boolean variable which enables removing of secrets from collected artifacts" split_words:"true"`
+	RedactionRules       []string      `default:"" desc:"Comma separated list of additional regexes of secrets. Use \x2c for a comma inside of regex" split_words:"true"`
+	NodeDiagnostics      bool          `default:"false" desc:"Boolean variable which enables collection of interfaces, routes, conntrack, hugepages and dmesg of nodes" split_words:"true"`
+	NodeDebugImage       string        `default:"nicolaka/netshoot:v0.13" desc:"Image of debug pods collecting diagnostics of nodes that are not kind containers" split_words:"true"`
 }
 
 // nolint: gocyclo
@@ -70,6 +94,7 @@ func initialize() {
 	}
 
 	matchRegex = regexp.MustCompile(config.AllowedNamespaces)
+	suiteRegex = regexp.MustCompile(config.SuiteNamespaces)
 	dockerRegex = regexp.MustCompile(config.AllowedContainers)
 
 	var singleClusterKubeConfig = os.Getenv("KUBECONFIG")
@@ -91,8 +116,27 @@ func initialize() {
 		kubeConfigs = append(kubeConfigs, singleClusterKubeConfig)
 	}
 
+	var err error
+	if mode, err = parseCollectionMode(config.Mode); err != nil {
+		logrus.Fatal(err.Error())
+	}
+	if !config.LogCollectionEnabled {
+		mode = &collectionMode{name: neverMode}
+	}
+	config.ArtifactsDir = artifactsDir(&config)
+	if artifactsSink, err = newSink(&config); err != nil {
+		logrus.Fatal(err.Error())
+	}
+	if artifactsRedactor, err = newRedactor(&config); err != nil {
+		logrus.Fatal(err.Error())
+	}
+
 	runner, _ = bash.New()
 
+	if externalRuntime, err = newContainerRuntime(config.ContainerRuntime, runCommand); err != nil {
+		logrus.Warnf("External containers will not be collected. Error: %s", err.Error())
+	}
+
 	ctx, _ = signal.NotifyContext(context.Background(),
 		os.Interrupt,
 		os.Kill,
@@ -101,66 +145,178 @@ func initialize() {
 		syscall.SIGQUIT,
 	)
 
-	clusterDumpSingleOperation = newSingleOperation()
+	clusterDumpScheduler = newDumpScheduler(config.DumpQueueSize, dumpClusters)
+}
+
+// ShouldCollect returns true if logs should be collected according to LOGS_MODE. failed is a result of the test or the suite.
+func ShouldCollect(failed bool) bool {
+	once.Do(func() { initialize() })
+	return mode.shouldCollect(failed)
+}
+
+// KubeConfigs returns kubeconfigs of all clusters: KUBECONFIG1..N or KUBECONFIG if none of them is set.
+func KubeConfigs() []string {
+	once.Do(func() { initialize() })
+	return append([]string(nil), kubeConfigs...)
+}
+
+// SuiteDir is a name of the artifacts dir with suite level logs. It can't collide with names of tests.
+const SuiteDir = "suite"
+
+// ClusterDump saves logs from all pods in specified namespaces. Tests that fail together share the same dump.
+func ClusterDump(suiteName, testName string, options ...Option) {
+	once.Do(func() { initialize() })
+
+	var opts = new(dumpOptions)
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	clusterDumpScheduler.Dump(&dumpRequest{
+		name:           filepath.Join(suiteName, testName),
+		since:          opts.since,
+		namespaces:     matchRegex,
+		collectors:     opts.collectors,
+		testNamespaces: opts.namespaces,
+	})
 }
 
-// ClusterDump saves logs from all pods in specified namespaces
-func ClusterDump(suiteName, testName string) {
+// SuiteDump saves full logs of namespaces shared by tests of the suite (nsm-system, spire, etc.) into the SuiteDir of the suite.
+func SuiteDump(suiteName string, options ...Option) {
 	once.Do(func() { initialize() })
-	clusterDumpSingleOperation.Run(func() {
-		if ctx.Err() != nil {
-			return
+
+	var opts = new(dumpOptions)
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	clusterDumpScheduler.Dump(&dumpRequest{name: filepath.Join(suiteName, SuiteDir), namespaces: suiteRegex, collectors: opts.collectors})
+}
+
+// Wait waits for all scheduled cluster dumps. Should be called before the process exits.
+func Wait() {
+	once.Do(func() { initialize() })
+	clusterDumpScheduler.Wait()
+}
+
+// dumpClusters creates one dump per cluster for the first request and hard links it for the rest of requests.
+func dumpClusters(batch []*dumpRequest) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	var manifest = &Manifest{Since: batch[0].since}
+	var namespaces = batch[0].namespaces
+	var collectorNames = enabledCollectors(batch)
+	var testNamespaces = make(map[string]bool)
+	for _, r := range batch {
+		manifest.Tests = append(manifest.Tests, filepath.ToSlash(r.name))
+		for _, ns := range r.testNamespaces {
+			testNamespaces[ns] = true
 		}
-		for i := range kubeConfigs {
-			suitedir := filepath.Join(config.ArtifactsDir, fmt.Sprintf("cluster%v", i), suiteName, testName)
-
-			nsString, _, _, _ := runner.Run(fmt.Sprintf(`kubectl --kubeconfig %v get ns -o go-template='{{range .items}}{{ .metadata.name }} {{end}}'`, kubeConfigs[i]))
-			nsList := strings.Split(nsString, " ")
-
-			_, _, exitCode, err := runner.Run(fmt.Sprintf("kubectl --kubeconfig %v cluster-info dump --output-directory=%s --namespaces %s",
-				kubeConfigs[i],
-				suitedir,
-				strings.Join(filterNamespaces(nsList), ",")))
-
-			if exitCode != 0 {
-				logrus.Errorf("An error while getting cluster dump. Exit Code: %v", exitCode)
-			}
-			if err != nil {
-				logrus.Errorf("An error while getting cluster dump. Error: %s", err.Error())
-			}
-
-			allContainers, _, _, err := runner.Run(`docker ps --format '{{.Names}}'`)
-			if err != nil {
-				logrus.Errorf("An error while getting docker containers. Error: %s", err.Error())
-				continue
-			}
-			containerList := strings.Split(strings.TrimSpace(allContainers), "\n")
-			containers := filterContainers(containerList)
-
-			for _, container := range containers {
-				_, _, dExitCode, dErr := runner.Run(fmt.Sprintf("docker logs %s >& %s/%s.log", container, suitedir, container))
-
-				if dExitCode != 0 {
-					logrus.Errorf("An error while getting docker logs. Exit Code: %v", dExitCode)
-				}
-				if dErr != nil {
-					logrus.Errorf("An error while getting docker logs. Error: %s", dErr.Error())
-				}
-			}
+		// Note: the shared dump should cover windows of all tests
+		if r.since.IsZero() || r.since.Before(manifest.Since) {
+			manifest.Since = r.since
 		}
-	})
+	}
+
+	for i := range kubeConfigs {
+		clusterDir := filepath.Join(config.ArtifactsDir, fmt.Sprintf("cluster%v", i))
+		collectArtifacts(clusterDir, batch, func(dir string) {
+			manifest.Cluster = filepath.Base(clusterDir)
+			dumpCluster(kubeConfigs[i], dir, namespaces, testNamespaces, collectorNames, manifest)
+		})
+		if config.NodeDiagnostics {
+			// Note: nodes are shared by all tests, so their diagnostics are kept once per cluster and show the state of the last dump
+			var d = &nodeDumper{kubeConfig: kubeConfigs[i], image: config.NodeDebugImage, runtime: externalRuntime, run: runCommand}
+			collectArtifacts(clusterDir, []*dumpRequest{{name: NodesDir}}, d.Dump)
+		}
+	}
+
+	if externalRuntime != nil {
+		collectArtifacts(filepath.Join(config.ArtifactsDir, ExternalDir), batch, dumpExternalContainers)
+	}
 }
 
-func filterNamespaces(nsList []string) []string {
-	result := make([]string, 0)
+// collectArtifacts runs collect in a staging dir, removes secrets and stores the result for every request of the batch.
+func collectArtifacts(rootDir string, batch []*dumpRequest, collect func(dir string)) {
+	suitedir := filepath.Join(rootDir, batch[0].name)
 
-	for i := range nsList {
-		if matchRegex.MatchString(nsList[i]) {
-			result = append(result, nsList[i])
+	// Note: secrets are removed before artifacts get into the artifacts dir
+	stagingDir, err := os.MkdirTemp("", "cluster-dump-")
+	if err != nil {
+		logrus.Errorf("An error while creating staging dir. Error: %s", err.Error())
+		return
+	}
+
+	collect(stagingDir)
+
+	if err = artifactsRedactor.RedactDir(stagingDir, suitedir); err != nil {
+		logrus.Errorf("An error while redacting cluster dump. Error: %s", err.Error())
+	}
+	_ = os.RemoveAll(stagingDir)
+
+	for _, r := range batch[1:] {
+		if err = linkDir(suitedir, filepath.Join(rootDir, r.name)); err != nil {
+			logrus.Errorf("An error while linking cluster dump. Error: %s", err.Error())
 		}
 	}
+	for _, r := range batch {
+		storeArtifacts(filepath.Join(rootDir, r.name))
+	}
+}
 
-	return result
+// dumpExternalContainers collects containers running outside of clusters. They are collected once per dump.
+func dumpExternalContainers(dir string) {
+	containers, err := externalRuntime.Containers()
+	if err != nil {
+		logrus.Errorf("An error while getting external containers. Error: %s", err.Error())
+		return
+	}
+	if err = externalRuntime.Dump(dir, filterContainers(containers)); err != nil {
+		logrus.Errorf("An error while getting external containers. Error: %s", err.Error())
+	}
+}
+
+func dumpCluster(kubeConfig, dir string, namespaces *regexp.Regexp, testNamespaces map[string]bool, collectorNames []string, manifest *Manifest) {
+	nsString, _, _, _ := runner.Run(fmt.Sprintf(`kubectl --kubeconfig %v get ns -o go-template='{{range .items}}{{ .metadata.name }}{{"\n"}}{{end}}'`, kubeConfig))
+	manifest.FullNamespaces, manifest.WindowedNamespaces = splitNamespaces(strings.Split(nsString, "\n"), manifest.Since, namespaces, suiteRegex, testNamespaces)
+
+	if len(manifest.FullNamespaces) > 0 {
+		runDumpCommand(fmt.Sprintf("kubectl --kubeconfig %v cluster-info dump --output-directory=%s --namespaces %s",
+			kubeConfig,
+			dir,
+			strings.Join(manifest.FullNamespaces, ",")))
+	} else {
+		runDumpCommand(fmt.Sprintf("kubectl --kubeconfig %v get nodes -o json > %v 2>&1", kubeConfig, filepath.Join(dir, "nodes.json")))
+	}
+
+	for _, ns := range manifest.WindowedNamespaces {
+		dumpWindowedNamespace(kubeConfig, dir, ns, manifest.Since)
+	}
+
+	runCollectors(&Cluster{KubeConfig: kubeConfig, Dir: dir, run: runCommand}, collectorNames)
+
+	manifest.Until = time.Now()
+	if err := manifest.write(dir); err != nil {
+		logrus.Errorf("An error while writing manifest. Error: %s", err.Error())
+	}
+}
+
+func runCommand(cmd string) (stdout string, exitCode int, err error) {
+	stdout, _, exitCode, err = runner.Run(cmd)
+	return stdout, exitCode, err
+}
+
+func storeArtifacts(dir string) {
+	name, err := filepath.Rel(config.ArtifactsDir, dir)
+	if err != nil {
+		logrus.Errorf("An error while storing artifacts. Error: %s", err.Error())
+		return
+	}
+	if storeErr := artifactsSink.Store(ctx, dir, filepath.ToSlash(name)); storeErr != nil {
+		logrus.Errorf("An error while storing artifacts. Error: %s", storeErr.Error())
+	}
 }
 
 func filterContainers(containerList []string) []string {
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ManifestFile is a name of the file with the dump manifest.
+const ManifestFile = "manifest.json"
+
+// Manifest describes the content of the cluster dump.
+type Manifest struct {
+	// Tests are tests that share the dump. Format: <suite>/<test>.
+	Tests   []string `json:"tests"`
+	Cluster string   `json:"cluster"`
+	// Since is a start of the time window for logs of shared namespaces. Zero means full logs.
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+	// FullNamespaces are namespaces of the test, full logs are collected for them.
+	FullNamespaces []string `json:"fullNamespaces"`
+	// WindowedNamespaces are namespaces shared between tests and namespaces of other tests, logs are collected for the time window only.
+	WindowedNamespaces []string `json:"windowedNamespaces"`
+}
+
+// ReadManifest reads the manifest of the dump dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	b, err := os.ReadFile(filepath.Clean(filepath.Join(dir, ManifestFile)))
+	if err != nil {
+		return nil, err
+	}
+	var result = new(Manifest)
+	if err = json.Unmarshal(b, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (m *Manifest) write(dir string) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFile), b, 0o600)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+const (
+	neverMode     = "never"
+	onFailureMode = "on-failure"
+	alwaysMode    = "always"
+	samplePrefix  = "sample:"
+)
+
+// collectionMode decides whether logs should be collected.
+type collectionMode struct {
+	name string
+	// percent of passed tests that should be collected in the sample mode
+	percent int
+}
+
+func parseCollectionMode(mode string) (*collectionMode, error) {
+	mode = strings.TrimSpace(mode)
+
+	switch mode {
+	case neverMode, onFailureMode, alwaysMode:
+		return &collectionMode{name: mode}, nil
+	}
+
+	if !strings.HasPrefix(mode, samplePrefix) {
+		return nil, fmt.Errorf("unknown logs mode %q, expected: never, on-failure, always or sample:N%%", mode)
+	}
+
+	percent, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(mode, samplePrefix), "%"))
+	if err != nil || percent < 0 || percent > 100 {
+		return nil, fmt.Errorf("invalid sample in logs mode %q, expected: sample:N%% where N is in [0, 100]", mode)
+	}
+
+	return &collectionMode{name: samplePrefix, percent: percent}, nil
+}
+
+// shouldCollect returns true if logs should be collected. Failures are always collected in the sample mode.
+func (m *collectionMode) shouldCollect(failed bool) bool {
+	switch m.name {
+	case neverMode:
+		return false
+	case alwaysMode:
+		return true
+	case samplePrefix:
+		// #nosec
+		return failed || rand.Intn(100) < m.percent
+	default:
+		return failed
+	}
+}
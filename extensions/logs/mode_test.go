@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CollectionMode(t *testing.T) {
+	for _, sample := range []struct {
+		mode           string
+		passed, failed bool
+	}{
+		{mode: "never", passed: false, failed: false},
+		{mode: "on-failure", passed: false, failed: true},
+		{mode: "always", passed: true, failed: true},
+		{mode: "sample:0%", passed: false, failed: true},
+		{mode: "sample:100%", passed: true, failed: true},
+	} {
+		m, err := parseCollectionMode(sample.mode)
+		require.NoError(t, err)
+		require.Equal(t, sample.passed, m.shouldCollect(false), sample.mode)
+		require.Equal(t, sample.failed, m.shouldCollect(true), sample.mode)
+	}
+
+	for _, invalid := range []string{"", "sometimes", "sample:", "sample:101%", "sample:-1%"} {
+		_, err := parseCollectionMode(invalid)
+		require.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// NodesDir is a name of the dir of the cluster artifacts with node diagnostics.
+	NodesDir          = "nodes"
+	nodeSectionPrefix = "==== node diagnostics: "
+	nodeSectionSuffix = " ===="
+)
+
+// debugPodRegex matches the message of kubectl debug with the name of the created pod.
+var debugPodRegex = regexp.MustCompile(`Creating debugging pod (\S+)`)
+
+// nodeDiagnostics are files with node diagnostics and commands producing them. Commands run in the network namespace of the node.
+var nodeDiagnostics = []struct {
+	file string
+	cmd  string
+}{
+	{"interfaces.txt", "ip -d address show"},
+	{"links.txt", "ip -s -d link show"},
+	{"routes.txt", "ip route show table all; ip -6 route show table all; ip rule show"},
+	{"conntrack.txt", "conntrack -L || cat /proc/net/nf_conntrack"},
+	{"hugepages.txt", "grep -i huge /proc/meminfo; grep . /sys/kernel/mm/hugepages/*/*"},
+	{"dmesg.txt", "dmesg"},
+}
+
+// nodeScript returns the script that prints all diagnostics of the node separated by section headers.
+func nodeScript() string {
+	var sb strings.Builder
+	for _, d := range nodeDiagnostics {
+		_, _ = fmt.Fprintf(&sb, "echo \"%v%v%v\"; (%v) 2>&1; ", nodeSectionPrefix, d.file, nodeSectionSuffix, d.cmd)
+	}
+	return sb.String()
+}
+
+// splitNodeOutput splits the output of nodeScript into files.
+func splitNodeOutput(output string) map[string]string {
+	var result = make(map[string]string)
+	var file string
+	var sb strings.Builder
+	for _, line := range strings.SplitAfter(output, "\n") {
+		var trimmed = strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, nodeSectionPrefix) && strings.HasSuffix(trimmed, nodeSectionSuffix) {
+			if file != "" {
+				result[file] = sb.String()
+			}
+			file = strings.TrimSuffix(strings.TrimPrefix(trimmed, nodeSectionPrefix), nodeSectionSuffix)
+			sb.Reset()
+			continue
+		}
+		_, _ = sb.WriteString(line)
+	}
+	if file != "" {
+		result[file] = sb.String()
+	}
+	return result
+}
+
+// nodeDumper collects diagnostics of nodes. Nodes of kind clusters are containers of the runtime, they are reached with exec.
+// Other nodes are reached with a privileged debug pod per node.
+type nodeDumper struct {
+	kubeConfig string
+	image      string
+	runtime    *containerRuntime
+	run        func(cmd string) (stdout string, exitCode int, err error)
+}
+
+// Dump stores diagnostics of every node into dir/<node>.
+func (d *nodeDumper) Dump(dir string) {
+	stdout, exitCode, err := d.run(fmt.Sprintf(`kubectl --kubeconfig %v get nodes -o jsonpath='{.items[*].metadata.name}'`, d.kubeConfig))
+	if err != nil || exitCode != 0 {
+		logrus.Errorf("An error while getting nodes. Exit Code: %v, Error: %v", exitCode, err)
+		return
+	}
+
+	var containers = make(map[string]bool)
+	if d.runtime != nil {
+		names, containersErr := d.runtime.Containers()
+		if containersErr != nil {
+			logrus.Warnf("An error while getting node containers. Error: %s", containersErr.Error())
+		}
+		for _, name := range names {
+			containers[name] = true
+		}
+	}
+
+	for _, node := range strings.Fields(stdout) {
+		var output string
+		if containers[node] {
+			output, err = d.exec(fmt.Sprintf("%v exec %v sh -c '%v'", d.runtime.cli, node, nodeScript()))
+		} else {
+			output, err = d.debug(node)
+		}
+		if err != nil {
+			logrus.Errorf("An error while collecting diagnostics of node %v. Error: %s", node, err.Error())
+			continue
+		}
+		if err = writeFiles(filepath.Join(dir, node), splitNodeOutput(output)); err != nil {
+			logrus.Errorf("An error while saving diagnostics of node %v. Error: %s", node, err.Error())
+		}
+	}
+}
+
+// debug runs the script in a privileged debug pod on the node and removes the pod.
+func (d *nodeDumper) debug(node string) (string, error) {
+	// Note: node debug pods share network and pid namespaces of the node, so tools of the image see the node state
+	output, err := d.exec(fmt.Sprintf("kubectl --kubeconfig %v debug node/%v -n default --image=%v --profile=sysadmin --attach=true -- sh -c '%v' 2>&1",
+		d.kubeConfig, node, d.image, nodeScript()))
+	if m := debugPodRegex.FindStringSubmatch(output); m != nil {
+		_, _, _ = d.run(fmt.Sprintf("kubectl --kubeconfig %v delete pod %v -n default --ignore-not-found --wait=false", d.kubeConfig, m[1]))
+	}
+	return output, err
+}
+
+func (d *nodeDumper) exec(cmd string) (string, error) {
+	stdout, exitCode, err := d.run(cmd)
+	if err != nil {
+		return "", err
+	}
+	if !strings.Contains(stdout, nodeSectionPrefix) {
+		return stdout, fmt.Errorf("command exited with code %v without diagnostics", exitCode)
+	}
+	return stdout, nil
+}
+
+func writeFiles(dir string, files map[string]string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
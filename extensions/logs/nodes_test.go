@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NodeDumper_ShouldUseContainersOfKindNodes(t *testing.T) {
+	var commands []string
+	var d = &nodeDumper{
+		kubeConfig: "/kubeconfig",
+		image:      "netshoot",
+		runtime: &containerRuntime{cli: "docker", run: func(cmd string) (string, int, error) {
+			return "kind-control-plane\nnsc-1\n", 0, nil
+		}},
+		run: func(cmd string) (string, int, error) {
+			commands = append(commands, cmd)
+			switch {
+			case strings.Contains(cmd, "get nodes"):
+				return "kind-control-plane worker-1", 0, nil
+			case strings.HasPrefix(cmd, "docker exec kind-control-plane"):
+				return nodeSectionPrefix + "interfaces.txt" + nodeSectionSuffix + "\n1: lo\n" +
+					nodeSectionPrefix + "dmesg.txt" + nodeSectionSuffix + "\n[0.0] Linux\n", 0, nil
+			case strings.Contains(cmd, "debug node/worker-1"):
+				return "Creating debugging pod node-debugger-worker-1-x2k4b with container debugger on node worker-1.\n" +
+					nodeSectionPrefix + "routes.txt" + nodeSectionSuffix + "\ndefault via 10.0.0.1\n", 0, nil
+			}
+			return "", 0, nil
+		},
+	}
+
+	var dir = t.TempDir()
+	d.Dump(dir)
+
+	for file, expected := range map[string]string{
+		filepath.Join("kind-control-plane", "interfaces.txt"): "1: lo\n",
+		filepath.Join("kind-control-plane", "dmesg.txt"):      "[0.0] Linux\n",
+		filepath.Join("worker-1", "routes.txt"):               "default via 10.0.0.1\n",
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(content))
+	}
+	require.Contains(t, commands, "kubectl --kubeconfig /kubeconfig delete pod node-debugger-worker-1-x2k4b -n default --ignore-not-found --wait=false")
+}
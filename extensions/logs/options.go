@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import "time"
+
+type dumpOptions struct {
+	since      time.Time
+	collectors []string
+	namespaces []string
+}
+
+// Option is an option pattern for ClusterDump
+type Option func(o *dumpOptions)
+
+// WithSince - collect logs of namespaces shared between tests (nsm-system, spire, etc.) only since the time. Usually it is a start time of the test
+func WithSince(since time.Time) Option {
+	return func(o *dumpOptions) {
+		o.since = since
+	}
+}
+
+// WithCollectors - run collectors of technologies used by the suite, e.g. vpp or spire. LOGS_COLLECTORS overrides them if it is set
+func WithCollectors(names ...string) Option {
+	return func(o *dumpOptions) {
+		o.collectors = append(o.collectors, names...)
+	}
+}
+
+// WithNamespaces - namespaces of the test, e.g. taken from its steps with StepNamespaces. Full logs are collected for them,
+// other namespaces are collected for the time window set by WithSince only
+func WithNamespaces(names ...string) Option {
+	return func(o *dumpOptions) {
+		o.namespaces = append(o.namespaces, names...)
+	}
+}
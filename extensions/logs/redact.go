@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	redacted = "[REDACTED]"
+	pcapExt  = ".pcap"
+)
+
+// RedactionRule replaces all matches of the Pattern with the Replacement. Replacement can refer to submatches, e.g. ${1}.
+type RedactionRule struct {
+	Name        string
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// builtinRules detect secrets that usually appear in cluster dumps and container logs.
+var builtinRules = []RedactionRule{
+	{
+		// Note: certificates are public, e.g. the SPIRE trust bundle, so only private keys are redacted
+		Name:        "pem",
+		Pattern:     regexp.MustCompile(`-----BEGIN ((?:[A-Z0-9]+ )*PRIVATE KEY)-----[\s\S]*?-----END (?:[A-Z0-9]+ )*PRIVATE KEY-----`),
+		Replacement: "-----BEGIN ${1}-----" + redacted + "-----END ${1}-----",
+	},
+	{
+		Name:        "jwt",
+		Pattern:     regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+		Replacement: redacted,
+	},
+	{
+		Name:        "spiffe-join-token",
+		Pattern:     regexp.MustCompile(`(?i)(join[_-]?token[^0-9a-f\n]{0,16})[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`),
+		Replacement: "${1}" + redacted,
+	},
+	{
+		Name:        "bearer",
+		Pattern:     regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`),
+		Replacement: "${1}" + redacted,
+	},
+	{
+		Name:        "kubeconfig",
+		Pattern:     regexp.MustCompile(`((?:client-key-data|client-certificate-data|certificate-authority-data|token|password)"?\s*:\s*"?)[^\s",}]+`),
+		Replacement: "${1}" + redacted,
+	},
+}
+
+// redactor scrubs secrets from collected artifacts.
+type redactor struct {
+	rules []RedactionRule
+	// sizeLimit is a max size of files written by RedactDir into the target dir, 0 means no limit.
+	sizeLimit int64
+}
+
+func newRedactor(c *Config) (*redactor, error) {
+	var result = &redactor{sizeLimit: c.SinkSizeLimit}
+
+	if !c.RedactionEnabled {
+		return result, nil
+	}
+
+	result.rules = append(result.rules, builtinRules...)
+
+	for i, rule := range c.RedactionRules {
+		pattern, err := regexp.Compile(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction rule %v: %w", rule, err)
+		}
+		result.rules = append(result.rules, RedactionRule{
+			Name:        fmt.Sprintf("custom-%v", i),
+			Pattern:     pattern,
+			Replacement: redacted,
+		})
+	}
+
+	return result, nil
+}
+
+// Redact returns data without secrets.
+func (r *redactor) Redact(data []byte) []byte {
+	for _, rule := range r.rules {
+		data = rule.Pattern.ReplaceAll(data, []byte(rule.Replacement))
+	}
+	return data
+}
+
+// RedactDir copies all files from the dir into the target dir and scrubs secrets on the way.
+// Files are copied in name order, files that don't fit in the size limit are skipped.
+func (r *redactor) RedactDir(dir, target string) error {
+	files, err := listArtifacts(dir)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, f := range files {
+		data, readErr := os.ReadFile(filepath.Clean(f.path))
+		if readErr != nil {
+			return readErr
+		}
+
+		var p = filepath.Join(target, filepath.FromSlash(f.rel))
+		if mkdirErr := os.MkdirAll(filepath.Dir(p), 0o750); mkdirErr != nil {
+			return mkdirErr
+		}
+		// Note: replacements change lengths of packets, so pcaps are stored as is
+		if filepath.Ext(p) != pcapExt {
+			data = r.Redact(data)
+		}
+		if r.sizeLimit > 0 && total+int64(len(data)) > r.sizeLimit {
+			logrus.Warnf("Artifact %v is skipped: size limit %v bytes is reached", p, r.sizeLimit)
+			continue
+		}
+		total += int64(len(data))
+		if writeErr := os.WriteFile(p, data, 0o600); writeErr != nil {
+			return writeErr
+		}
+	}
+
+	return nil
+}
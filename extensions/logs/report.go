@@ -0,0 +1,375 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ReportDir is a name of the artifacts dir with the HTML report.
+	ReportDir = "report"
+	// ReportFile is a name of the HTML report file.
+	ReportFile   = "index.html"
+	logTailLines = 50
+)
+
+// Event is a Kubernetes event.
+type Event struct {
+	Time    time.Time
+	Cluster string
+	Type    string
+	Reason  string
+	Object  string
+	Message string
+	Count   int
+}
+
+type podLog struct {
+	Namespace string
+	Pod       string
+	Link      string
+	Tail      string
+}
+
+type clusterReport struct {
+	Name     string
+	Manifest *Manifest
+	Logs     []podLog
+}
+
+type testReport struct {
+	Name     string
+	Failed   bool
+	Steps    []Step
+	Clusters []*clusterReport
+	Events   []TimelineEvent
+	Summary  []string
+	// watched is true if events come from the timeline of the test rather than from cluster dumps.
+	watched bool
+}
+
+type suiteReport struct {
+	Name  string
+	Tests []*testReport
+}
+
+type report struct {
+	Generated time.Time
+	Suites    []*suiteReport
+}
+
+// WriteReport generates the HTML report from the artifacts dir and stores it.
+func WriteReport() {
+	once.Do(func() { initialize() })
+
+	collectArtifacts(config.ArtifactsDir, []*dumpRequest{{name: ReportDir}}, func(dir string) {
+		f, err := os.Create(filepath.Join(dir, ReportFile))
+		if err != nil {
+			logrus.Errorf("An error while writing report. Error: %s", err.Error())
+			return
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		if err = GenerateReport(config.ArtifactsDir, f); err != nil {
+			logrus.Errorf("An error while writing report. Error: %s", err.Error())
+		}
+	})
+}
+
+// GenerateReport writes self-contained HTML report for artifacts from the dir. Links in the report are relative to the ReportDir.
+func GenerateReport(artifactsDir string, w io.Writer) error {
+	r, err := buildReport(artifactsDir)
+	if err != nil {
+		return err
+	}
+	return reportTemplate.Execute(w, r)
+}
+
+func buildReport(artifactsDir string) (*report, error) {
+	var tests = make(map[string]*testReport)
+	var result = &report{Generated: time.Now()}
+
+	getTest := func(suiteName, testName string) *testReport {
+		var key = suiteName + "/" + testName
+		if tests[key] == nil {
+			tests[key] = &testReport{Name: testName}
+			var s *suiteReport
+			for _, existing := range result.Suites {
+				if existing.Name == suiteName {
+					s = existing
+				}
+			}
+			if s == nil {
+				s = &suiteReport{Name: suiteName}
+				result.Suites = append(result.Suites, s)
+			}
+			s.Tests = append(s.Tests, tests[key])
+		}
+		return tests[key]
+	}
+
+	stepFiles, err := filepath.Glob(filepath.Join(artifactsDir, StepsDir, "*", "*", StepsFile))
+	if err != nil {
+		return nil, err
+	}
+	for _, stepFile := range stepFiles {
+		var dir = filepath.Dir(stepFile)
+		steps, readErr := ReadSteps(dir)
+		if readErr != nil {
+			return nil, readErr
+		}
+		var test = getTest(filepath.Base(filepath.Dir(dir)), filepath.Base(dir))
+		test.Steps = steps
+		if result, resultErr := ReadResult(dir); resultErr == nil {
+			test.Failed = result.Failed
+		}
+		if timeline, timelineErr := ReadTimeline(dir); timelineErr == nil {
+			test.Events, test.watched = timeline, true
+		}
+	}
+
+	manifests, err := filepath.Glob(filepath.Join(artifactsDir, "cluster*", "*", "*", ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	for _, manifestFile := range manifests {
+		var dir = filepath.Dir(manifestFile)
+		var test = getTest(filepath.Base(filepath.Dir(dir)), filepath.Base(dir))
+		cluster, clusterErr := readClusterReport(artifactsDir, dir)
+		if clusterErr != nil {
+			return nil, clusterErr
+		}
+		test.Clusters = append(test.Clusters, cluster)
+		if !test.watched {
+			test.Events = append(test.Events, readEvents(dir, cluster)...)
+		}
+	}
+
+	sort.Slice(result.Suites, func(i, j int) bool { return result.Suites[i].Name < result.Suites[j].Name })
+	for _, s := range result.Suites {
+		sort.Slice(s.Tests, func(i, j int) bool { return s.Tests[i].Name < s.Tests[j].Name })
+		for _, t := range s.Tests {
+			sort.SliceStable(t.Events, func(i, j int) bool { return t.Events[i].Time.Before(t.Events[j].Time) })
+			t.Summary = analyze(t)
+			t.Failed = t.Failed || failedStep(t.Steps) != nil
+		}
+	}
+
+	return result, nil
+}
+
+func readClusterReport(artifactsDir, dir string) (*clusterReport, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = &clusterReport{Name: manifest.Cluster, Manifest: manifest}
+	var full = make(map[string]bool)
+	for _, ns := range manifest.FullNamespaces {
+		full[ns] = true
+	}
+
+	logFiles, err := filepath.Glob(filepath.Join(dir, "*", "*", podLogsFile))
+	if err != nil {
+		return nil, err
+	}
+	for _, logFile := range logFiles {
+		var podDir = filepath.Dir(logFile)
+		var l = podLog{Namespace: filepath.Base(filepath.Dir(podDir)), Pod: filepath.Base(podDir)}
+		if rel, relErr := filepath.Rel(artifactsDir, logFile); relErr == nil {
+			l.Link = "../" + filepath.ToSlash(rel)
+		}
+		if full[l.Namespace] {
+			l.Tail = tail(logFile, logTailLines)
+		}
+		result.Logs = append(result.Logs, l)
+	}
+
+	return result, nil
+}
+
+// readEvents reads events of the dump that happened in the time window of the manifest.
+func readEvents(dir string, cluster *clusterReport) []TimelineEvent {
+	var result []TimelineEvent
+
+	eventFiles, _ := filepath.Glob(filepath.Join(dir, "*", "events.json"))
+	for _, eventFile := range eventFiles {
+		events, err := ReadEvents(eventFile)
+		if err != nil {
+			continue
+		}
+		for i := range events {
+			events[i].Cluster = cluster.Name
+			if !cluster.Manifest.Since.IsZero() && events[i].Time.Before(cluster.Manifest.Since) {
+				continue
+			}
+			result = append(result, TimelineEvent{Event: events[i], Step: noStep})
+		}
+	}
+
+	return result
+}
+
+// ReadEvents reads events from the file in kubectl get events -o json format.
+func ReadEvents(p string) ([]Event, error) {
+	b, err := os.ReadFile(filepath.Clean(p))
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Items []kubeEvent `json:"items"`
+	}
+	if err = json.Unmarshal(b, &list); err != nil {
+		return nil, err
+	}
+
+	var result = make([]Event, 0, len(list.Items))
+	for i := range list.Items {
+		result = append(result, list.Items[i].toEvent())
+	}
+
+	return result, nil
+}
+
+// analyze returns short human readable findings for the test.
+func analyze(t *testReport) []string {
+	var result []string
+
+	if step := failedStep(t.Steps); step != nil {
+		result = append(result, fmt.Sprintf("Step %v failed with exit code %v after %v attempts in %v: %v", step.Index, step.ExitCode, step.Attempts, step.Duration, step.Command))
+	} else if t.Failed {
+		result = append(result, "The test failed on an assertion, all steps succeeded")
+	}
+
+	var reasons = make(map[string]int)
+	for i := range t.Events {
+		if t.Events[i].Type == "Warning" {
+			reasons[t.Events[i].Reason]++
+		}
+	}
+	var names []string
+	for reason := range reasons {
+		names = append(names, reason)
+	}
+	sort.Strings(names)
+	for _, reason := range names {
+		result = append(result, fmt.Sprintf("Warning events %v: %v", reason, reasons[reason]))
+	}
+
+	return result
+}
+
+func failedStep(steps []Step) *Step {
+	for i := range steps {
+		if steps[i].Failed {
+			return &steps[i]
+		}
+	}
+	return nil
+}
+
+func tail(p string, n int) string {
+	b, err := os.ReadFile(filepath.Clean(p))
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimRight(string(b), "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"time": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format("15:04:05.000")
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Integration tests report</title>
+<style>
+body { font-family: sans-serif; margin: 1em 2em; }
+pre { background: #f4f4f4; padding: .5em; overflow-x: auto; white-space: pre-wrap; }
+table { border-collapse: collapse; width: 100%; }
+td, th { border: 1px solid #ddd; padding: 2px 6px; text-align: left; vertical-align: top; font-size: 90%; }
+.failed { background: #fde2e2; }
+.warning { color: #a15c00; }
+details.test > summary { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Integration tests report</h1>
+<p>Generated: {{.Generated.UTC.Format "2006-01-02 15:04:05 MST"}}</p>
+{{range .Suites}}
+<h2>{{.Name}}</h2>
+{{range .Tests}}
+<details class="test"{{if .Failed}} open{{end}}>
+<summary{{if .Failed}} class="failed"{{end}}>{{.Name}}</summary>
+{{with .Summary}}<h4>Summary</h4><ul>{{range .}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{with .Steps}}
+<h4>Steps</h4>
+<table>
+<tr><th>#</th><th>Start</th><th>Duration</th><th>Attempts</th><th>Exit code</th><th>Command</th></tr>
+{{range .}}
+<tr{{if .Failed}} class="failed"{{end}}>
+<td>{{.Index}}</td><td>{{time .Start}}</td><td>{{.Duration}}</td><td>{{.Attempts}}</td><td>{{.ExitCode}}</td>
+<td><code>{{.Command}}</code>{{if or .Stdout .Stderr}}
+<details{{if .Failed}} open{{end}}><summary>output</summary>{{with .Stdout}}<pre>{{.}}</pre>{{end}}{{with .Stderr}}<pre class="warning">{{.}}</pre>{{end}}</details>{{end}}</td>
+</tr>
+{{end}}
+</table>
+{{end}}
+{{with .Events}}
+<h4>Events</h4>
+<table>
+<tr><th>Time</th><th>Step</th><th>Cluster</th><th>Type</th><th>Reason</th><th>Object</th><th>Message</th></tr>
+{{range .}}<tr{{if eq .Type "Warning"}} class="warning"{{end}}><td>{{time .Time}}</td><td>{{if ge .Step 0}}{{.Step}}{{end}}</td><td>{{.Cluster}}</td><td>{{.Type}}</td><td>{{.Reason}}</td><td>{{.Object}}</td><td>{{.Message}}</td></tr>
+{{end}}
+</table>
+{{end}}
+{{range .Clusters}}
+<h4>Logs: {{.Name}} ({{time .Manifest.Since}} - {{time .Manifest.Until}})</h4>
+<ul>
+{{range .Logs}}<li><a href="{{.Link}}">{{.Namespace}}/{{.Pod}}</a>{{with .Tail}}<details><summary>tail</summary><pre>{{.}}</pre></details>{{end}}</li>
+{{end}}
+</ul>
+{{end}}
+</details>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GenerateReport_ShouldLinkStepsLogsAndEvents(t *testing.T) {
+	var root = t.TempDir()
+	var since = time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	steps, err := json.Marshal([]Step{
+		{Index: 0, Command: "kubectl apply -k .", Start: since, Duration: time.Second},
+		{Index: 1, Command: "kubectl exec pods/alpine -- ping -c 4 172.16.1.100", Start: since.Add(time.Second), Duration: time.Minute,
+			Failed: true, Attempts: 12, ExitCode: 1, Stdout: "4 packets transmitted, 0 received", Stderr: "ping: timeout"},
+	})
+	require.NoError(t, err)
+	writeLog(t, filepath.Join(root, StepsDir, "Suite", "TestKernel2Kernel", StepsFile), string(steps))
+	writeLog(t, filepath.Join(root, StepsDir, "Suite", "TestKernel2Kernel", ResultFile), `{"failed": true}`)
+
+	steps, err = json.Marshal([]Step{{Index: 0, Command: "kubectl apply -k .", Start: since, Duration: time.Second}})
+	require.NoError(t, err)
+	writeLog(t, filepath.Join(root, StepsDir, "Suite", "TestAssertion", StepsFile), string(steps))
+	writeLog(t, filepath.Join(root, StepsDir, "Suite", "TestAssertion", ResultFile), `{"failed": true}`)
+
+	var testDir = filepath.Join(root, "cluster0", "Suite", "TestKernel2Kernel")
+	manifest, err := json.Marshal(&Manifest{Cluster: "cluster0", Since: since, Until: since.Add(time.Minute), FullNamespaces: []string{"ns-kernel2kernel"}})
+	require.NoError(t, err)
+	writeLog(t, filepath.Join(testDir, ManifestFile), string(manifest))
+	writeLog(t, filepath.Join(testDir, "ns-kernel2kernel", "alpine", podLogsFile), "first line\nlast line of alpine")
+	writeLog(t, filepath.Join(testDir, "ns-kernel2kernel", "events.json"), `{"items": [
+		{"type": "Warning", "reason": "BackOff", "message": "Back-off restarting failed container", "lastTimestamp": "2024-01-02T10:00:30Z",
+		 "involvedObject": {"kind": "Pod", "namespace": "ns-kernel2kernel", "name": "alpine"}},
+		{"type": "Normal", "reason": "Pulled", "message": "old event", "lastTimestamp": "2024-01-02T09:00:00Z",
+		 "involvedObject": {"kind": "Pod", "namespace": "ns-kernel2kernel", "name": "alpine"}}
+	]}`)
+
+	var sb strings.Builder
+	require.NoError(t, GenerateReport(root, &sb))
+
+	var html = sb.String()
+	require.Contains(t, html, `<tr class="failed">`)
+	require.Contains(t, html, "Step 1 failed with exit code 1 after 12 attempts in 1m0s")
+	require.Contains(t, html, "<details open><summary>output</summary><pre>4 packets transmitted, 0 received</pre>")
+	require.Contains(t, html, "ping: timeout")
+	require.Contains(t, html, `<summary class="failed">TestAssertion</summary>`)
+	require.Contains(t, html, "The test failed on an assertion, all steps succeeded")
+	require.Contains(t, html, "Warning events BackOff: 1")
+	require.Contains(t, html, `href="../cluster0/Suite/TestKernel2Kernel/ns-kernel2kernel/alpine/logs.txt"`)
+	require.Contains(t, html, "last line of alpine")
+	require.NotContains(t, html, "old event")
+}
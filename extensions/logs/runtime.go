@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	autoRuntime = "auto"
+	// ExternalDir is a name of the artifacts dir with containers running outside of clusters.
+	ExternalDir = "external"
+	// ContainersFile is a name of the file with statuses and exit codes of external containers.
+	ContainersFile = "containers.txt"
+)
+
+// knownRuntimes are container runtime CLIs in the detection order. All of them have docker compatible commands.
+var knownRuntimes = []string{"docker", "podman", "nerdctl"}
+
+// containerRuntime collects containers that run outside of clusters, e.g. NSC and NSE in k8s_monolith suites.
+type containerRuntime struct {
+	cli string
+	run func(cmd string) (stdout string, exitCode int, err error)
+}
+
+// newContainerRuntime returns the runtime for the CLI. "auto" selects the first of known runtimes installed on the host.
+func newContainerRuntime(cli string, run func(cmd string) (string, int, error)) (*containerRuntime, error) {
+	if cli != autoRuntime {
+		return &containerRuntime{cli: cli, run: run}, nil
+	}
+	for _, known := range knownRuntimes {
+		if _, exitCode, err := run("command -v " + known); err == nil && exitCode == 0 {
+			return &containerRuntime{cli: known, run: run}, nil
+		}
+	}
+	return nil, fmt.Errorf("no container runtime found, tried: %v", strings.Join(knownRuntimes, ", "))
+}
+
+// Containers returns names of all containers including exited ones.
+func (r *containerRuntime) Containers() ([]string, error) {
+	stdout, exitCode, err := r.run(fmt.Sprintf(`%v ps -a --format '{{.Names}}'`, r.cli))
+	if err != nil {
+		return nil, err
+	}
+	if exitCode != 0 {
+		return nil, fmt.Errorf("%v ps exited with code %v", r.cli, exitCode)
+	}
+	return strings.Fields(stdout), nil
+}
+
+// Dump stores logs, inspect output, statuses and exit codes of the containers into the dir.
+func (r *containerRuntime) Dump(dir string, containers []string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	var statuses strings.Builder
+	for _, c := range containers {
+		r.save(fmt.Sprintf("%v logs %v", r.cli, c), filepath.Join(dir, c+".log"))
+		r.save(fmt.Sprintf("%v inspect %v", r.cli, c), filepath.Join(dir, c+".inspect.json"))
+
+		status, exitCode, err := r.run(fmt.Sprintf(`%v inspect --format '{{.State.Status}} {{.State.ExitCode}}' %v`, r.cli, c))
+		if err != nil || exitCode != 0 {
+			status = "unknown"
+		}
+		_, _ = fmt.Fprintf(&statuses, "%v %v\n", c, strings.TrimSpace(status))
+	}
+
+	return os.WriteFile(filepath.Join(dir, ContainersFile), []byte(statuses.String()), 0o600)
+}
+
+func (r *containerRuntime) save(cmd, file string) {
+	_, exitCode, err := r.run(fmt.Sprintf("%v > %v 2>&1", cmd, file))
+	if err != nil {
+		logrus.Errorf("An error while getting %v. Error: %s", file, err.Error())
+		return
+	}
+	if exitCode != 0 {
+		logrus.Errorf("An error while getting %v. Exit Code: %v", file, exitCode)
+	}
+}
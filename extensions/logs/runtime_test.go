@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/gotestmd/pkg/bash"
+)
+
+const fakeRuntimeCLI = `#!/bin/sh
+case "$1" in
+  ps) printf 'nsc-1\nnse-1\n' ;;
+  logs) echo "logs of $2" ;;
+  inspect)
+    if [ "$2" = "--format" ]; then
+      [ "$4" = "nse-1" ] && echo "exited 3" || echo "running 0"
+    else
+      echo "[{\"Name\": \"$2\"}]"
+    fi ;;
+  *) exit 1 ;;
+esac
+`
+
+func Test_ContainerRuntime_ShouldDumpContainers(t *testing.T) {
+	var cli = filepath.Join(t.TempDir(), "fake-runtime")
+	require.NoError(t, os.WriteFile(cli, []byte(fakeRuntimeCLI), 0o700)) // #nosec
+
+	b, err := bash.New()
+	require.NoError(t, err)
+	defer b.Close()
+
+	r, err := newContainerRuntime(cli, func(cmd string) (string, int, error) {
+		stdout, _, exitCode, runErr := b.Run(cmd)
+		return stdout, exitCode, runErr
+	})
+	require.NoError(t, err)
+
+	containers, err := r.Containers()
+	require.NoError(t, err)
+	require.Equal(t, []string{"nsc-1", "nse-1"}, containers)
+
+	var dir = filepath.Join(t.TempDir(), "external")
+	require.NoError(t, r.Dump(dir, containers))
+
+	for file, expected := range map[string]string{
+		"nsc-1.log":          "logs of nsc-1\n",
+		"nse-1.inspect.json": "[{\"Name\": \"nse-1\"}]\n",
+		ContainersFile:       "nsc-1 running 0\nnse-1 exited 3\n",
+	} {
+		content, readErr := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, readErr)
+		require.Equal(t, expected, string(content))
+	}
+}
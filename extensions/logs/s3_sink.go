@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm      = "AWS4-HMAC-SHA256"
+	s3Service        = "s3"
+	s3DateLayout     = "20060102"
+	s3DateTimeLayout = "20060102T150405Z"
+	s3SignedHeaders  = "host;x-amz-content-sha256;x-amz-date"
+)
+
+// s3Sink uploads artifacts into the S3 compatible object store (AWS S3, MinIO, etc.) using path-style requests.
+type s3Sink struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	prefix    string
+	client    *http.Client
+	now       func() time.Time
+}
+
+func newS3Sink(c *Config) (*s3Sink, error) {
+	if c.S3Endpoint == "" || c.S3Bucket == "" {
+		return nil, fmt.Errorf("s3 sink requires endpoint and bucket")
+	}
+	u, err := url.Parse(c.S3Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Sink{
+		endpoint:  u,
+		bucket:    c.S3Bucket,
+		region:    c.S3Region,
+		accessKey: c.S3AccessKey,
+		secretKey: c.S3SecretKey,
+		prefix:    c.SinkPrefix,
+		client:    &http.Client{Timeout: c.S3UploadTimeout},
+		now:       time.Now,
+	}, nil
+}
+
+func (s *s3Sink) Store(ctx context.Context, dir, name string) error {
+	files, err := listArtifacts(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err = s.upload(ctx, path.Join(s.prefix, name, f.rel), f.path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *s3Sink) upload(ctx context.Context, key, p string) error {
+	payloadHash, err := fileSHA256(p)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(filepath.Clean(p))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	u := *s.endpoint
+	u.Path = path.Join("/", u.Path, s.bucket, key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size()
+	s.sign(req, payloadHash)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("can't upload %v: %v: %s", key, resp.Status, body)
+	}
+
+	return nil
+}
+
+// sign signs the request with AWS Signature Version 4.
+func (s *s3Sink) sign(req *http.Request, payloadHash string) {
+	var now = s.now().UTC()
+	var date = now.Format(s3DateLayout)
+	var dateTime = now.Format(s3DateTimeLayout)
+
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	req.Header.Set("x-amz-date", dateTime)
+
+	var canonicalRequest = strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + dateTime,
+		"",
+		s3SignedHeaders,
+		payloadHash,
+	}, "\n")
+
+	var scope = strings.Join([]string{date, s.region, s3Service, "aws4_request"}, "/")
+	var stringToSign = strings.Join([]string{s3Algorithm, dateTime, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	var key = hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%v Credential=%v/%v, SignedHeaders=%v, Signature=%v",
+		s3Algorithm, s.accessKey, scope, s3SignedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))))
+}
+
+func fileSHA256(p string) (string, error) {
+	f, err := os.Open(filepath.Clean(p))
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
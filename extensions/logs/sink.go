@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	dirSinkKind = "dir"
+	tarSinkKind = "tar"
+	s3SinkKind  = "s3"
+)
+
+// Sink stores artifacts collected by ClusterDump.
+type Sink interface {
+	// Store saves content of the local dir. name is a slash separated relative name of the dir, for example cluster0/suite/test.
+	Store(ctx context.Context, dir, name string) error
+}
+
+// artifactFile is a regular file that should be stored by a sink.
+type artifactFile struct {
+	path string
+	rel  string
+	size int64
+}
+
+// newSink creates the sink of the config. The dir sink without its own dir keeps artifacts in place,
+// the prefix of the run is a part of the artifacts dir then, see artifactsDir.
+func newSink(c *Config) (Sink, error) {
+	var targetDir = c.SinkDir
+	if targetDir == "" {
+		targetDir = c.ArtifactsDir
+	}
+
+	switch c.Sink {
+	case dirSinkKind:
+		if c.SinkDir == "" {
+			return new(dirSink), nil
+		}
+		return &dirSink{root: filepath.Join(targetDir, c.SinkPrefix)}, nil
+	case tarSinkKind:
+		return &tarSink{root: filepath.Join(targetDir, c.SinkPrefix)}, nil
+	case s3SinkKind:
+		return newS3Sink(c)
+	default:
+		return nil, fmt.Errorf("unknown sink: %v", c.Sink)
+	}
+}
+
+// artifactsDir returns the dir artifacts are collected into. The dir sink without its own dir stores artifacts in place,
+// so the prefix of the run is added to the artifacts dir to keep runs apart.
+func artifactsDir(c *Config) string {
+	if c.Sink == dirSinkKind && c.SinkDir == "" {
+		return filepath.Join(c.ArtifactsDir, c.SinkPrefix)
+	}
+	return c.ArtifactsDir
+}
+
+// listArtifacts returns files of the dir sorted by name.
+func listArtifacts(dir string) ([]artifactFile, error) {
+	var result []artifactFile
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		result = append(result, artifactFile{path: p, rel: filepath.ToSlash(rel), size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].rel < result[j].rel
+	})
+
+	return result, nil
+}
+
+// dirSink copies artifacts into the local directory. Artifacts are kept in place if the root is empty.
+type dirSink struct {
+	root string
+}
+
+func (s *dirSink) Store(ctx context.Context, dir, name string) error {
+	if s.root == "" {
+		return nil
+	}
+	var target = filepath.Join(s.root, filepath.FromSlash(name))
+
+	files, err := listArtifacts(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if copyErr := copyFile(f.path, filepath.Join(target, filepath.FromSlash(f.rel))); copyErr != nil {
+			return copyErr
+		}
+	}
+
+	return nil
+}
+
+func copyFile(from, to string) error {
+	src, err := os.Open(filepath.Clean(from))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	if err = os.MkdirAll(filepath.Dir(to), 0o750); err != nil {
+		return err
+	}
+
+	dst, err := os.Create(filepath.Clean(to))
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+
+	return dst.Close()
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// objectStore is a minimal stand-in for MinIO that keeps uploaded objects in memory.
+type objectStore struct {
+	// secrets are secret keys by access keys.
+	secrets map[string]string
+
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+// verify checks AWS Signature Version 4 of the request the way the object store does.
+func (o *objectStore) verify(r *http.Request, payloadHash string) bool {
+	var fields = make(map[string]string)
+	for _, field := range strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), s3Algorithm+" "), ", ") {
+		if kv := strings.SplitN(field, "=", 2); len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+	var credential = strings.SplitN(fields["Credential"], "/", 2)
+	if len(credential) != 2 {
+		return false
+	}
+	secret, ok := o.secrets[credential[0]]
+	if !ok {
+		return false
+	}
+	var scope = strings.Split(credential[1], "/")
+	if len(scope) != 4 {
+		return false
+	}
+
+	var canonicalHeaders []string
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		var value = r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders = append(canonicalHeaders, name+":"+strings.TrimSpace(value))
+	}
+	var canonicalRequest = strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.RawQuery,
+		strings.Join(canonicalHeaders, "\n"),
+		"",
+		fields["SignedHeaders"],
+		payloadHash,
+	}, "\n")
+	var stringToSign = strings.Join([]string{s3Algorithm, r.Header.Get("x-amz-date"), credential[1], sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	var key = []byte("AWS4" + secret)
+	for _, part := range scope {
+		key = hmacSHA256(key, part)
+	}
+	return hmac.Equal([]byte(fields["Signature"]), []byte(hex.EncodeToString(hmacSHA256(key, stringToSign))))
+}
+
+func (o *objectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil || r.Header.Get("x-amz-content-sha256") != sha256Hex(body) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !o.verify(r, sha256Hex(body)) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.objects[r.URL.Path] = string(body)
+}
+
+func createArtifacts(t *testing.T) string {
+	var dir = filepath.Join(t.TempDir(), "cluster0", "suite", "test")
+	writeLog(t, filepath.Join(dir, "nsm-system", "nsmgr-a", podLogsFile), "nsmgr logs")
+	writeLog(t, filepath.Join(dir, "nsc.log"), "nsc logs")
+	return dir
+}
+
+func Test_S3Sink_ShouldUploadArtifacts(t *testing.T) {
+	var store = &objectStore{secrets: map[string]string{"minio": "minio123"}, objects: make(map[string]string)}
+	var server = httptest.NewServer(store)
+	defer server.Close()
+
+	sink, err := newSink(&Config{
+		Sink:            s3SinkKind,
+		SinkPrefix:      "run-1",
+		S3Endpoint:      server.URL,
+		S3Bucket:        "artifacts",
+		S3Region:        "us-east-1",
+		S3AccessKey:     "minio",
+		S3SecretKey:     "minio123",
+		S3UploadTimeout: time.Second,
+	})
+	require.NoError(t, err)
+	sink.(*s3Sink).now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	require.NoError(t, sink.Store(context.Background(), createArtifacts(t), "cluster0/suite/test"))
+	require.Equal(t, map[string]string{
+		"/artifacts/run-1/cluster0/suite/test/nsc.log":                     "nsc logs",
+		"/artifacts/run-1/cluster0/suite/test/nsm-system/nsmgr-a/logs.txt": "nsmgr logs",
+	}, store.objects)
+
+	sink.(*s3Sink).secretKey = "wrong"
+	require.Error(t, sink.Store(context.Background(), createArtifacts(t), "cluster0/suite/test"))
+
+	sink.(*s3Sink).secretKey = "minio123"
+	sink.(*s3Sink).region = "eu-west-1"
+	sink.(*s3Sink).now = time.Now
+	require.NoError(t, sink.Store(context.Background(), createArtifacts(t), "cluster0/suite/test"))
+
+	sink.(*s3Sink).accessKey = "unknown"
+	require.Error(t, sink.Store(context.Background(), createArtifacts(t), "cluster0/suite/test"))
+}
+
+func Test_TarSink_ShouldPackArtifacts(t *testing.T) {
+	var root = t.TempDir()
+
+	sink, err := newSink(&Config{Sink: tarSinkKind, SinkDir: root, SinkPrefix: "run-1"})
+	require.NoError(t, err)
+	require.NoError(t, sink.Store(context.Background(), createArtifacts(t), "cluster0/suite/test"))
+
+	f, err := os.Open(filepath.Join(root, "run-1", "cluster0", "suite", "test.tar.gz"))
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	var names []string
+	for {
+		header, nextErr := tr.Next()
+		if nextErr == io.EOF {
+			break
+		}
+		require.NoError(t, nextErr)
+		names = append(names, header.Name)
+	}
+	require.Equal(t, []string{"test/nsc.log", "test/nsm-system/nsmgr-a/logs.txt"}, names)
+}
+
+func Test_DirSink_ShouldKeepArtifactsInPlace(t *testing.T) {
+	var config = &Config{Sink: dirSinkKind, ArtifactsDir: t.TempDir(), SinkPrefix: "run-1"}
+	require.Equal(t, filepath.Join(config.ArtifactsDir, "run-1"), artifactsDir(config))
+
+	sink, err := newSink(config)
+	require.NoError(t, err)
+	require.NoError(t, sink.Store(context.Background(), createArtifacts(t), "cluster0/suite/test"))
+
+	entries, err := os.ReadDir(config.ArtifactsDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func Test_RedactDir_ShouldRespectSizeLimit(t *testing.T) {
+	var target = t.TempDir()
+
+	r, err := newRedactor(&Config{SinkSizeLimit: 8})
+	require.NoError(t, err)
+	require.NoError(t, r.RedactDir(createArtifacts(t), target))
+
+	files, err := listArtifacts(target)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "nsc.log", files[0].rel)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// StepsDir is a name of the artifacts dir with steps of tests.
+	StepsDir = "steps"
+	// StepsFile is a name of the file with steps of the test.
+	StepsFile = "steps.json"
+	// ResultFile is a name of the file with the result of the test.
+	ResultFile = "result.json"
+	// CapturesDir is a name of the dir with packet captures of failed steps.
+	CapturesDir = "captures"
+)
+
+// Step is a record of the command executed by the test.
+type Step struct {
+	Index    int           `json:"index"`
+	Dir      string        `json:"dir"`
+	Command  string        `json:"command"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	// Failed is true if the command has not succeeded until the timeout.
+	Failed   bool   `json:"failed"`
+	Attempts int    `json:"attempts"`
+	ExitCode int    `json:"exitCode"`
+	Stdout   string `json:"stdout,omitempty"`
+	Stderr   string `json:"stderr,omitempty"`
+	// Captures are pcaps recorded while the step ran. SaveSteps moves them into the artifacts dir and makes paths relative.
+	Captures []string `json:"captures,omitempty"`
+}
+
+// Result is a result of the test. A test can fail on an assertion without a failed step.
+type Result struct {
+	Failed bool `json:"failed"`
+}
+
+// SaveSteps stores steps and the result of the test into the artifacts dir. Use SuiteDir as testName for steps of the suite setup.
+// Steps can be saved again with steps of cleanups appended, captures of saved steps are kept in place.
+func SaveSteps(suiteName, testName string, result Result, steps []Step) {
+	once.Do(func() { initialize() })
+
+	var name = filepath.Join(suiteName, testName)
+	collectArtifacts(filepath.Join(config.ArtifactsDir, StepsDir), []*dumpRequest{{name: name}}, func(dir string) {
+		for i := range steps {
+			steps[i].Captures = moveCaptures(dir, &steps[i])
+		}
+		err := writeJSON(filepath.Join(dir, StepsFile), steps)
+		if err == nil {
+			err = writeJSON(filepath.Join(dir, ResultFile), result)
+		}
+		if err != nil {
+			logrus.Errorf("An error while saving steps. Error: %s", err.Error())
+		}
+	})
+}
+
+// ReadSteps reads steps stored by SaveSteps from the dir.
+func ReadSteps(dir string) ([]Step, error) {
+	b, err := os.ReadFile(filepath.Clean(filepath.Join(dir, StepsFile)))
+	if err != nil {
+		return nil, err
+	}
+	var result []Step
+	if err = json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReadResult reads the result of the test stored by SaveSteps from the dir.
+func ReadResult(dir string) (*Result, error) {
+	b, err := os.ReadFile(filepath.Clean(filepath.Join(dir, ResultFile)))
+	if err != nil {
+		return nil, err
+	}
+	var result = new(Result)
+	if err = json.Unmarshal(b, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func writeJSON(p string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, b, 0o600)
+}
+
+func moveCaptures(dir string, step *Step) []string {
+	var result []string
+	for _, p := range step.Captures {
+		if !filepath.IsAbs(p) {
+			// Note: the step is saved again with steps of cleanups, its captures are already moved
+			result = append(result, p)
+			continue
+		}
+		var rel = filepath.Join(CapturesDir, fmt.Sprint(step.Index), filepath.Base(p))
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(rel)), 0o750); err != nil {
+			logrus.Errorf("An error while saving captures. Error: %s", err.Error())
+			return result
+		}
+		if err := copyFile(p, filepath.Join(dir, rel)); err != nil {
+			logrus.Errorf("An error while saving captures. Error: %s", err.Error())
+			continue
+		}
+		_ = os.Remove(p)
+		_ = os.Remove(filepath.Dir(p))
+		result = append(result, filepath.ToSlash(rel))
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// tarSink packs artifacts into the <root>/<name>.tar.gz archive.
+type tarSink struct {
+	root string
+}
+
+func (s *tarSink) Store(ctx context.Context, dir, name string) error {
+	files, err := listArtifacts(dir)
+	if err != nil {
+		return err
+	}
+
+	var archivePath = filepath.Join(s.root, filepath.FromSlash(name)+".tar.gz")
+	if err = os.MkdirAll(filepath.Dir(archivePath), 0o750); err != nil {
+		return err
+	}
+
+	out, err := os.Create(filepath.Clean(archivePath))
+	if err != nil {
+		return err
+	}
+
+	if err = writeTarGz(ctx, out, files, path.Base(name)); err != nil {
+		_ = out.Close()
+		_ = os.Remove(archivePath)
+		return err
+	}
+
+	return out.Close()
+}
+
+func writeTarGz(ctx context.Context, w io.Writer, files []artifactFile, baseDir string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, f := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := addTarFile(tw, f, baseDir); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+func addTarFile(tw *tar.Writer, f artifactFile, baseDir string) error {
+	src, err := os.Open(filepath.Clean(f.path))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = path.Join(baseDir, f.rel)
+
+	if err = tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err = io.CopyN(tw, src, header.Size)
+
+	return err
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// podContainersTemplate lists init and regular containers of pods, one "<pod> <container>" per line.
+const podContainersTemplate = `{{range .items}}{{$p := .metadata.name}}{{range .spec.initContainers}}{{$p}} {{.name}}{{"\n"}}{{end}}{{range .spec.containers}}{{$p}} {{.name}}{{"\n"}}{{end}}{{end}}`
+
+// namespacedKinds are kinds that kubectl cluster-info dump stores for each namespace.
+var namespacedKinds = []string{"events", "pods", "services", "deployments", "daemonsets", "replicasets", "replicationcontrollers"}
+
+// splitNamespaces splits namespaces matched by the regex into namespaces of the test and other namespaces.
+// Namespaces of the test are the ones used by its steps, they belong to the test even if they existed before the test started,
+// so logs of reused namespaces are collected in full. Namespaces shared between tests, e.g. nsm-system, and namespaces of other tests
+// running in parallel are windowed. All namespaces belong to the test if since is zero.
+func splitNamespaces(names []string, since time.Time, namespaces, sharedNamespaces *regexp.Regexp, testNamespaces map[string]bool) (own, windowed []string) {
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || !namespaces.MatchString(name) {
+			continue
+		}
+		if !since.IsZero() && (!testNamespaces[name] || sharedNamespaces.MatchString(name)) {
+			windowed = append(windowed, name)
+			continue
+		}
+		own = append(own, name)
+	}
+	return own, windowed
+}
+
+// dumpWindowedNamespace stores the same content as kubectl cluster-info dump does, but container logs are limited by the time window.
+// Logs of init containers are collected as well.
+func dumpWindowedNamespace(kubeConfig, dir, namespace string, since time.Time) {
+	var nsDir = filepath.Join(dir, namespace)
+	if err := os.MkdirAll(nsDir, 0o750); err != nil {
+		logrus.Errorf("An error while creating namespace dir. Error: %s", err.Error())
+		return
+	}
+
+	for _, kind := range namespacedKinds {
+		runDumpCommand(fmt.Sprintf("kubectl --kubeconfig %v get %v -n %v -o json > %v 2>&1",
+			kubeConfig, kind, namespace, filepath.Join(nsDir, kind+".json")))
+	}
+
+	containers, _, _, err := runner.Run(fmt.Sprintf(`kubectl --kubeconfig %v get pods -n %v -o go-template='%v'`, kubeConfig, namespace, podContainersTemplate))
+	if err != nil {
+		logrus.Errorf("An error while getting containers. Error: %s", err.Error())
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(containers), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		pod, container := fields[0], fields[1]
+		if mkdirErr := os.MkdirAll(filepath.Join(nsDir, pod), 0o750); mkdirErr != nil {
+			logrus.Errorf("An error while creating pod dir. Error: %s", mkdirErr.Error())
+			continue
+		}
+		runDumpCommand(fmt.Sprintf(`{ echo "==== START logs for container %[4]v of pod %[3]v/%[5]v ===="; kubectl --kubeconfig %[1]v logs -n %[3]v %[5]v -c %[4]v --since-time=%[2]v; echo "==== END logs for container %[4]v of pod %[3]v/%[5]v ===="; } >> %[6]v 2>&1`,
+			kubeConfig, since.UTC().Format(time.RFC3339), namespace, container, pod, filepath.Join(nsDir, pod, podLogsFile)))
+	}
+}
+
+func runDumpCommand(cmd string) {
+	_, _, exitCode, err := runner.Run(cmd)
+	if err != nil {
+		logrus.Errorf("An error while getting cluster dump. Error: %s", err.Error())
+		return
+	}
+	if exitCode != 0 {
+		logrus.Errorf("An error while getting cluster dump. Exit Code: %v", exitCode)
+	}
+}
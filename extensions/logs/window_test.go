@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SplitNamespaces_ShouldCollectOnlyNamespacesOfTestInFull(t *testing.T) {
+	var namespaces = regexp.MustCompile("(ns-.*)|(nsm-system)|(spire)")
+	var sharedNamespaces = regexp.MustCompile("(nsm-system)|(spire)")
+	// Note: steps of the test can use shared namespaces too, e.g. kubectl get pods -n nsm-system
+	var testNamespaces = map[string]bool{"ns-kernel2kernel": true, "nsm-system": true}
+
+	// Note: ns-kernel2kernel could be created by an earlier test and reused, it still belongs to the test.
+	// ns-memif2memif belongs to the test running in parallel.
+	var names = []string{"default", "nsm-system", "spire", "ns-kernel2kernel", "ns-memif2memif", ""}
+
+	own, windowed := splitNamespaces(names, time.Date(2024, 1, 2, 10, 0, 5, 500, time.UTC), namespaces, sharedNamespaces, testNamespaces)
+	require.Equal(t, []string{"ns-kernel2kernel"}, own)
+	require.Equal(t, []string{"nsm-system", "spire", "ns-memif2memif"}, windowed)
+
+	own, windowed = splitNamespaces(names, time.Time{}, namespaces, sharedNamespaces, nil)
+	require.Equal(t, []string{"nsm-system", "spire", "ns-kernel2kernel", "ns-memif2memif"}, own)
+	require.Empty(t, windowed)
+}
+
+func Test_PodContainersTemplate_ShouldListInitContainers(t *testing.T) {
+	var pods map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"items": [
+		{"metadata": {"name": "nsc"}, "spec": {"initContainers": [{"name": "init"}], "containers": [{"name": "nsc"}, {"name": "alpine"}]}},
+		{"metadata": {"name": "nse"}, "spec": {"containers": [{"name": "nse"}]}}
+	]}`), &pods))
+
+	var out strings.Builder
+	require.NoError(t, template.Must(template.New("").Parse(podContainersTemplate)).Execute(&out, pods))
+	require.Equal(t, "nsc init\nnsc nsc\nnsc alpine\nnse nse\n", out.String())
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2023-2024 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -58,14 +58,6 @@ func Run(t *testing.T, s suite.TestingSuite, options ...Option) {
 	tests := []testing.InternalTest{}
 	methodFinder := reflect.TypeOf(s)
 
-	t.Cleanup(func() {
-		if suiteSetupDone {
-			if tearDownAllSuite, ok := s.(suite.TearDownAllSuite); ok {
-				tearDownAllSuite.TearDownSuite()
-			}
-		}
-	})
-
 	for i := 0; i < methodFinder.NumMethod(); i++ {
 		method := methodFinder.Method(i)
 		if ok := strings.HasPrefix(method.Name, "Test"); !ok {
@@ -77,11 +69,16 @@ func Run(t *testing.T, s suite.TestingSuite, options ...Option) {
 		}
 
 		if !suiteSetupDone {
-			if setupAllSuite, ok := s.(suite.SetupAllSuite); ok {
-				setupAllSuite.SetupSuite()
-			}
+			setupSuite(t, s)
 
 			suiteSetupDone = true
+
+			// Note: cleanup is registered after SetupSuite so TearDownSuite runs before cleanups of the setup
+			t.Cleanup(func() {
+				if tearDownAllSuite, ok := s.(suite.TearDownAllSuite); ok {
+					tearDownAllSuite.TearDownSuite()
+				}
+			})
 		}
 
 		test := newTest(t, s, methodFinder, &method, parallel)
@@ -99,6 +96,31 @@ func Run(t *testing.T, s suite.TestingSuite, options ...Option) {
 	}
 }
 
+// SetupSuiteFailure is an interface for suites that should be notified when SetupSuite fails.
+// Only Run notifies suites, testify suite.Run doesn't know about the interface.
+type SetupSuiteFailure interface {
+	HandleSetupSuiteFailure()
+}
+
+func setupSuite(t *testing.T, s suite.TestingSuite) {
+	setupAllSuite, ok := s.(suite.SetupAllSuite)
+	if !ok {
+		return
+	}
+
+	var setupDone bool
+	defer func() {
+		if !setupDone || t.Failed() {
+			if setupSuiteFailure, isFailureHandler := s.(SetupSuiteFailure); isFailureHandler {
+				setupSuiteFailure.HandleSetupSuiteFailure()
+			}
+		}
+	}()
+
+	setupAllSuite.SetupSuite()
+	setupDone = true
+}
+
 func newTest(t *testing.T, s suite.TestingSuite, methodFinder reflect.Type, method *reflect.Method, parallel bool) testing.InternalTest {
 	return testing.InternalTest{
 		Name: method.Name,
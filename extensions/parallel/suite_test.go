@@ -1,5 +1,7 @@
 // Copyright (c) 2024 Pragmagic Inc. and/or its affiliates.
 //
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
 // SPDX-License-Identifier: Apache-2.0
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -19,6 +21,7 @@ package parallel_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/goleak"
 
@@ -55,3 +58,28 @@ func Test_OptionWithRunningTestsSynchronously_ShouldExcludeTestsFromParallelExec
 	var s2 = new(positiveSuite)
 	parallel.Run(t, s2, parallel.WithRunningTestsSynchronously(s2.TestSynchronously))
 }
+
+type orderSuite struct {
+	suite.Suite
+	events *[]string
+}
+
+func (s *orderSuite) SetupSuite() {
+	s.T().Cleanup(func() { *s.events = append(*s.events, "setup cleanup") })
+}
+
+func (s *orderSuite) TearDownSuite() {
+	*s.events = append(*s.events, "teardown")
+}
+
+func (s *orderSuite) TestParallel() {}
+
+func Test_TearDownSuite_ShouldRunBeforeSetupCleanup(t *testing.T) {
+	var events []string
+
+	t.Run("suite", func(t *testing.T) {
+		parallel.Run(t, &orderSuite{events: &events})
+	})
+
+	require.Equal(t, []string{"teardown", "setup cleanup"}, events)
+}
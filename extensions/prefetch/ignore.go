@@ -1,5 +1,7 @@
 // Copyright (c) 2021 Doc.ai and/or its affiliates.
 //
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
 // SPDX-License-Identifier: Apache-2.0
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -20,5 +22,6 @@ import "regexp"
 
 var (
 	// IsExcluded is using for filtering applications that should not be used in the prefetching.
-	IsExcluded = regexp.MustCompile("(.*-sriov)|(.*-vfio)").MatchString
+	// It is applied to names of files and to paths of kustomizations, e.g. examples/sriov or use-cases/SriovKernel2Noop.
+	IsExcluded = regexp.MustCompile("(?i)(sriov)|(vfio)").MatchString
 )
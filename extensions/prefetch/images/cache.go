@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var commitRegex = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// imageCache keeps resolved images of GitHub sources on disk. Entries are stored in <dir>/<owner>/<repo>/<ref>/<hash of the source>.json.
+// Note: the match func of ReteriveList is not a part of the key, callers sharing the cache dir should use the same one.
+type imageCache struct {
+	dir string
+	ttl time.Duration
+}
+
+type cacheEntry struct {
+	Source    string     `json:"source"`
+	Time      time.Time  `json:"time"`
+	Images    []string   `json:"images"`
+	Overrides []Override `json:"overrides,omitempty"`
+}
+
+// get returns the cached manifest of the source. Returns nil if the source is not cached, expired or can't be cached.
+func (c *imageCache) get(source string) *Manifest {
+	if c == nil {
+		return nil
+	}
+	var p, commit = c.path(source)
+	if p == "" {
+		return nil
+	}
+	b, err := os.ReadFile(filepath.Clean(p))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err = json.Unmarshal(b, &entry); err != nil || entry.Source != source {
+		return nil
+	}
+	if !commit && time.Since(entry.Time) > c.ttl {
+		return nil
+	}
+	return &Manifest{Images: entry.Images, Overrides: entry.Overrides}
+}
+
+// put stores the manifest of the source.
+func (c *imageCache) put(source string, manifest *Manifest) {
+	if c == nil {
+		return
+	}
+	var p, _ = c.path(source)
+	if p == "" {
+		return
+	}
+	b, err := json.Marshal(&cacheEntry{Source: source, Time: time.Now(), Images: manifest.Images, Overrides: manifest.Overrides})
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(p), 0o750)
+	}
+	if err == nil {
+		// Note: concurrent test processes share the cache, so the entry is replaced atomically
+		err = writeAtomically(p, b)
+	}
+	if err != nil {
+		logrus.Warnf("An error while caching images of %v. Error: %s", source, err.Error())
+	}
+}
+
+// path returns the cache file of the source and whether the source is pinned to a commit.
+// Returns empty string for sources that are not in GitHub repositories.
+func (c *imageCache) path(source string) (string, bool) {
+	owner, repo, ref := githubRef(source)
+	if owner == "" || ref == "" {
+		return "", false
+	}
+	return filepath.Join(c.dir, owner, repo, ref, hashOf(source)+".json"), commitRegex.MatchString(ref)
+}
+
+// githubRef returns the repository and the ref of raw.githubusercontent.com and api.github.com sources.
+func githubRef(source string) (owner, repo, ref string) {
+	if strings.HasPrefix(source, "https://api.github.com/") {
+		s, err := parseGithubSource(source)
+		if err != nil || s.ref == "HEAD" {
+			return "", "", ""
+		}
+		return s.owner, s.repo, url.PathEscape(s.ref)
+	}
+	if p, ok := strings.CutPrefix(source, "https://raw.githubusercontent.com/"); ok {
+		var segments = strings.SplitN(p, "/", 4)
+		if len(segments) == 4 {
+			return segments[0], segments[1], segments[2]
+		}
+	}
+	return "", "", ""
+}
+
+func writeAtomically(p string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(p), filepath.Base(p)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+	if _, err = f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+func hashOf(s string) string {
+	var sum = sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
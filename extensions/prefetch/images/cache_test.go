@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReteriveList_ShouldCacheGithubSources(t *testing.T) {
+	var g = &githubStandIn{files: githubFiles}
+	withGithubStandIn(t, g)
+
+	var dir = t.TempDir()
+	var sources = []string{"https://api.github.com/repos/owner/repo/contents/apps?ref=v1.0.0"}
+
+	first, err := ReteriveList(sources, yamlMatch, WithCache(dir, time.Hour))
+	require.NoError(t, err)
+	var requests = len(g.requests)
+	require.NotZero(t, requests)
+
+	second, err := ReteriveList(sources, yamlMatch, WithCache(dir, time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, first.Images, second.Images)
+	require.Len(t, g.requests, requests, "cached sources should be resolved without requests")
+
+	_, err = ReteriveList(sources, yamlMatch, WithCache(dir, 0))
+	require.NoError(t, err)
+	require.Greater(t, len(g.requests), requests, "sources of tags and branches should expire")
+}
+
+func Test_ImageCache_ShouldNotExpireCommits(t *testing.T) {
+	var c = &imageCache{dir: t.TempDir()}
+	var manifest = &Manifest{Images: []string{"alpine"}}
+
+	for _, source := range []string{
+		"https://raw.githubusercontent.com/owner/repo/c91be290/external-images.yaml",
+		"https://api.github.com/repos/owner/repo/contents/apps?ref=c91be29099fab1f8376d9ff90c858efd829de35e",
+	} {
+		c.put(source, manifest)
+		require.Equal(t, manifest, c.get(source), source)
+	}
+
+	for _, source := range []string{
+		"https://raw.githubusercontent.com/owner/repo/main/external-images.yaml",
+		"https://api.github.com/repos/owner/repo/contents/apps",
+		"file://samples/alpine.yaml",
+	} {
+		c.put(source, manifest)
+		require.Nil(t, c.get(source), source)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// githubTokenEnv is an env variable with the token used for requests to GitHub. Unauthenticated requests are limited to 60 per hour.
+const githubTokenEnv = "GITHUB_TOKEN"
+
+var (
+	githubAPIURL = "https://api.github.com"
+	githubRawURL = "https://raw.githubusercontent.com"
+)
+
+type githubTree struct {
+	Tree []struct {
+		Path string `json:"path"`
+		Type string `json:"type"`
+		SHA  string `json:"sha"`
+	} `json:"tree"`
+	Truncated bool `json:"truncated"`
+}
+
+// githubSource is a file or a dir of the repository in format https://api.github.com/repos/<owner>/<repo>/contents/<path>?ref=<ref>.
+type githubSource struct {
+	owner, repo, path, ref string
+}
+
+func parseGithubSource(rawurl string) (*githubSource, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	var segments = strings.SplitN(strings.Trim(u.Path, "/"), "/", 5)
+	if len(segments) < 4 || segments[0] != "repos" || segments[3] != "contents" {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedSource, rawurl)
+	}
+	var result = &githubSource{owner: segments[1], repo: segments[2], ref: u.Query().Get("ref")}
+	if len(segments) == 5 {
+		result.path = segments[4]
+	}
+	if result.ref == "" {
+		result.ref = "HEAD"
+	}
+	return result, nil
+}
+
+// reteriveGithubFileList returns raw URLs of files of the GitHub source. The whole tree of the repository is fetched by a single
+// request of the git trees API. If the tree is too large for a single response, it is listed dir by dir.
+func reteriveGithubFileList(rawurl string, match func(string) bool) ([]string, error) {
+	source, err := parseGithubSource(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := source.tree(url.PathEscape(source.ref), true)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	if tree.Truncated {
+		files, err = source.walk(url.PathEscape(source.ref), "")
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, entry := range tree.Tree {
+			if entry.Type == "blob" {
+				files = append(files, entry.Path)
+			}
+		}
+	}
+
+	var result []string
+	for _, file := range files {
+		if source.contains(file) && (file == source.path || match(path.Base(file))) {
+			result = append(result, fmt.Sprintf("%v/%v/%v/%v/%v", githubRawURL, source.owner, source.repo, source.ref, file))
+		}
+	}
+	return result, nil
+}
+
+// contains returns true if the file is the source or is located in the source dir.
+func (s *githubSource) contains(file string) bool {
+	return s.path == "" || file == s.path || strings.HasPrefix(file, s.path+"/")
+}
+
+// walk lists files of the tree without recursive requests. Only dirs on the way to the source path and inside it are listed.
+func (s *githubSource) walk(sha, dir string) ([]string, error) {
+	tree, err := s.tree(sha, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, entry := range tree.Tree {
+		var p = path.Join(dir, entry.Path)
+		switch entry.Type {
+		case "blob":
+			result = append(result, p)
+		case "tree":
+			if !s.contains(p) && !strings.HasPrefix(s.path, p+"/") {
+				continue
+			}
+			files, err := s.walk(entry.SHA, p)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, files...)
+		}
+	}
+	return result, nil
+}
+
+func (s *githubSource) tree(sha string, recursive bool) (*githubTree, error) {
+	var treeURL = fmt.Sprintf("%v/repos/%v/%v/git/trees/%v", githubAPIURL, s.owner, s.repo, sha)
+	if recursive {
+		treeURL += "?recursive=1"
+	}
+	b, err := httpGet(treeURL)
+	if err != nil {
+		return nil, err
+	}
+	var result = new(githubTree)
+	if err := json.Unmarshal(b, result); err != nil {
+		return nil, fmt.Errorf("unexpected response of %v: %w", treeURL, err)
+	}
+	return result, nil
+}
+
+// isGithubURL returns true if requests to the url should be authenticated by the GitHub token.
+func isGithubURL(rawurl string) bool {
+	return strings.HasPrefix(rawurl, githubAPIURL+"/") || strings.HasPrefix(rawurl, githubRawURL+"/")
+}
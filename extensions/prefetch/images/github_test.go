@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// githubStandIn serves git trees and raw files of the repository owner/repo with files of the map.
+type githubStandIn struct {
+	files     map[string]string
+	truncated bool
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (g *githubStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	g.requests = append(g.requests, r.URL.RequestURI()+" "+r.Header.Get("Authorization"))
+	g.mu.Unlock()
+
+	if p, ok := strings.CutPrefix(r.URL.Path, "/raw/owner/repo/v1.0.0/"); ok {
+		if content, ok := g.files[p]; ok {
+			_, _ = w.Write([]byte(content))
+			return
+		}
+	}
+	if sha, ok := strings.CutPrefix(r.URL.Path, "/api/repos/owner/repo/git/trees/"); ok {
+		var dir = strings.TrimPrefix(strings.TrimPrefix(sha, "v1.0.0"), "sha:")
+		var recursive = r.URL.Query().Get("recursive") == "1"
+		_ = json.NewEncoder(w).Encode(g.tree(dir, recursive))
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// tree returns entries of the dir. Subtrees have the sha in format sha:<dir>.
+func (g *githubStandIn) tree(dir string, recursive bool) map[string]interface{} {
+	var entries []map[string]string
+	var seen = make(map[string]bool)
+	if recursive && g.truncated {
+		return map[string]interface{}{"tree": entries, "truncated": true}
+	}
+	for file := range g.files {
+		var rel = file
+		if dir != "" {
+			var ok bool
+			if rel, ok = strings.CutPrefix(file, dir+"/"); !ok {
+				continue
+			}
+		}
+		var parts = strings.Split(rel, "/")
+		for i := range parts {
+			var p = strings.Join(parts[:i+1], "/")
+			if seen[p] || (!recursive && i > 0) {
+				continue
+			}
+			seen[p] = true
+			if i == len(parts)-1 {
+				entries = append(entries, map[string]string{"path": p, "type": "blob", "sha": "blob"})
+			} else {
+				entries = append(entries, map[string]string{"path": p, "type": "tree", "sha": "sha:" + strings.TrimPrefix(dir+"/"+p, "/")})
+			}
+		}
+	}
+	return map[string]interface{}{"tree": entries, "truncated": false}
+}
+
+func withGithubStandIn(t *testing.T, g *githubStandIn) {
+	server := httptest.NewServer(g)
+	t.Cleanup(server.Close)
+
+	var apiURL, rawURL = githubAPIURL, githubRawURL
+	githubAPIURL, githubRawURL = server.URL+"/api", server.URL+"/raw"
+	t.Cleanup(func() { githubAPIURL, githubRawURL = apiURL, rawURL })
+}
+
+var githubFiles = map[string]string{
+	"apps/nsc-kernel/nsc.yaml":       "images:\n- nsc\n",
+	"apps/nsc-kernel/kustomization":  "images:\n- ignored\n",
+	"apps/nse-kernel/nse.yaml":       "images:\n- nse\n",
+	"apps/nse-kernel/patch/nse.yaml": "images:\n- nse-patched\n",
+	"examples/basic/client.yaml":     "images:\n- client\n",
+}
+
+func Test_ReteriveList_ShouldUseGitTreesWithToken(t *testing.T) {
+	t.Setenv(githubTokenEnv, "secret")
+	var g = &githubStandIn{files: githubFiles}
+	withGithubStandIn(t, g)
+
+	list, err := ReteriveList([]string{
+		"https://api.github.com/repos/owner/repo/contents/apps?ref=v1.0.0",
+		"https://api.github.com/repos/owner/repo/contents/examples/basic/client.yaml?ref=v1.0.0",
+	}, yamlMatch)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"nsc", "nse", "nse-patched", "client"}, list.Images)
+
+	var treeRequests int
+	for _, r := range g.requests {
+		require.True(t, strings.HasSuffix(r, " Bearer secret"), r)
+		if strings.Contains(r, "/git/trees/") {
+			require.Contains(t, r, "recursive=1")
+			treeRequests++
+		}
+	}
+	require.Equal(t, 2, treeRequests)
+}
+
+func Test_ReteriveList_ShouldWalkTruncatedTrees(t *testing.T) {
+	var g = &githubStandIn{files: githubFiles, truncated: true}
+	withGithubStandIn(t, g)
+
+	list, err := ReteriveList([]string{"https://api.github.com/repos/owner/repo/contents/apps/nse-kernel?ref=v1.0.0"}, yamlMatch)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"nse", "nse-patched"}, list.Images)
+
+	for _, r := range g.requests {
+		require.NotContains(t, r, "examples", "dirs out of the source path should not be listed")
+	}
+}
+
+func yamlMatch(s string) bool {
+	return strings.HasSuffix(s, ".yaml")
+}
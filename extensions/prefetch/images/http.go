@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const noRetry time.Duration = -1
+
+var (
+	// retryAttempts is a max number of attempts of requests failing with transient errors.
+	retryAttempts = 5
+	// retryBackoff is a delay before the first retry, it doubles on each next retry.
+	retryBackoff = time.Second
+	// maxRateLimitWait is a max time to wait for the reset of the GitHub rate limit.
+	maxRateLimitWait = time.Minute
+	httpClient       = &http.Client{Timeout: time.Minute}
+)
+
+// httpGet returns the body of the url. Transient failures are retried with exponential backoff,
+// rate limited requests are retried after the reset of the limit if it comes soon enough.
+func httpGet(rawurl string) ([]byte, error) {
+	var backoff = retryBackoff
+	for attempt := 1; ; attempt++ {
+		b, wait, err := tryGet(rawurl)
+		if err == nil {
+			return b, nil
+		}
+		if wait == noRetry || attempt >= retryAttempts {
+			return nil, err
+		}
+		if wait == 0 {
+			wait = backoff
+			backoff *= 2
+		}
+		logrus.Warnf("GET %v has failed, retrying in %v. Error: %s", rawurl, wait, err.Error())
+		time.Sleep(wait)
+	}
+}
+
+// tryGet does a single request. Returns the delay before the retry: noRetry for permanent errors and 0 for the default backoff.
+func tryGet(rawurl string) ([]byte, time.Duration, error) {
+	req, err := http.NewRequest(http.MethodGet, rawurl, http.NoBody)
+	if err != nil {
+		return nil, noRetry, err
+	}
+	if token := os.Getenv(githubTokenEnv); token != "" && isGithubURL(rawurl) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, transportWait(err), err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusOK {
+		b, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			return nil, transportWait(readErr), readErr
+		}
+		return b, 0, nil
+	}
+
+	var httpErr = &HTTPError{URL: rawurl, StatusCode: resp.StatusCode, Status: resp.Status}
+	if wait, limited := rateLimitWait(resp); limited {
+		if wait > maxRateLimitWait {
+			return nil, noRetry, &RateLimitError{URL: rawurl, Reset: time.Now().Add(wait)}
+		}
+		return nil, wait, httpErr
+	}
+	if httpErr.Temporary() {
+		return nil, 0, httpErr
+	}
+	return nil, noRetry, httpErr
+}
+
+// transportWait returns the delay before the retry of the request failed with the transport error.
+// Only timeouts and dropped connections are retried. Unknown hosts, refused connections and other errors
+// are permanent, e.g. when there is no network, so they fail at once.
+func transportWait(err error) time.Duration {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		if dnsErr.IsTimeout || dnsErr.IsTemporary {
+			return 0
+		}
+		return noRetry
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return 0
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return 0
+	}
+	return noRetry
+}
+
+// rateLimitWait returns the time until the GitHub rate limit resets if the response is rate limited.
+// Primary rate limits are reported by X-RateLimit-* headers, secondary ones by Retry-After.
+func rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if after, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(after) * time.Second, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, true
+	}
+	if wait := time.Until(time.Unix(reset, 0)); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func withFastRetries(t *testing.T) {
+	var attempts, backoff = retryAttempts, retryBackoff
+	retryAttempts, retryBackoff = 3, time.Millisecond
+	t.Cleanup(func() { retryAttempts, retryBackoff = attempts, backoff })
+}
+
+func Test_HTTPGet_ShouldRetryTransientFailures(t *testing.T) {
+	withFastRetries(t)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("image: alpine"))
+	}))
+	defer server.Close()
+
+	b, err := httpGet(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, "image: alpine", string(b))
+	require.EqualValues(t, 3, requests)
+}
+
+func Test_HTTPGet_ShouldNotRetryPermanentFailures(t *testing.T) {
+	withFastRetries(t)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := httpGet(server.URL)
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	require.EqualValues(t, 1, requests)
+}
+
+func Test_HTTPGet_ShouldRetryOnlyTransientTransportErrors(t *testing.T) {
+	withFastRetries(t)
+
+	var client = httpClient
+	httpClient = &http.Client{Timeout: 10 * time.Millisecond}
+	t.Cleanup(func() { httpClient = client })
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 2 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte("image: alpine"))
+	}))
+	defer server.Close()
+
+	b, err := httpGet(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, "image: alpine", string(b))
+	require.EqualValues(t, 2, requests)
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	_, wait, err := tryGet(closed.URL)
+	require.Error(t, err)
+	require.Equal(t, noRetry, wait)
+
+	_, wait, err = tryGet("http://unknown.invalid")
+	require.Error(t, err)
+	require.Equal(t, noRetry, wait)
+}
+
+func Test_HTTPGet_ShouldHandleRateLimits(t *testing.T) {
+	withFastRetries(t)
+
+	var reset = time.Now().Add(time.Hour).Truncate(time.Second)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n = atomic.AddInt32(&requests, 1)
+		switch {
+		case r.URL.Path == "/secondary" && n == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path == "/secondary":
+			_, _ = w.Write([]byte("ok"))
+		default:
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	b, err := httpGet(server.URL + "/secondary")
+	require.NoError(t, err)
+	require.Equal(t, "ok", string(b))
+
+	atomic.StoreInt32(&requests, 0)
+	_, err = httpGet(server.URL + "/primary")
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	require.WithinDuration(t, reset, rateLimitErr.Reset, time.Second)
+	require.EqualValues(t, 1, requests)
+}
+
+func Test_ReteriveList_ShouldReportFailedSources(t *testing.T) {
+	var dir = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty.yaml"), []byte("kind: ConfigMap\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "alpine.yaml"), []byte("images:\n- alpine\n"), 0o600))
+
+	list, err := ReteriveList([]string{
+		"file://" + filepath.Join(dir, "alpine.yaml"),
+		"file://" + filepath.Join(dir, "empty.yaml"),
+		"file://" + filepath.Join(dir, "missing.yaml"),
+		"ftp://example.com/images.yaml",
+	}, func(string) bool { return true })
+
+	require.Equal(t, []string{"alpine"}, list.Images)
+	require.ErrorIs(t, err, ErrNoImages)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.ErrorIs(t, err, ErrUnsupportedSource)
+
+	var sourceErr *SourceError
+	require.True(t, errors.As(err, &sourceErr))
+	require.Equal(t, "file://"+filepath.Join(dir, "empty.yaml"), sourceErr.Source)
+}
+
+func Test_ReteriveList_ShouldKeepImagesOfReadableFiles(t *testing.T) {
+	var dir = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "busybox.yaml"), []byte("images:\n- busybox\n"), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "missing.yaml"), filepath.Join(dir, "broken.yaml")))
+	var empty = filepath.Join(t.TempDir(), "namespace.yaml")
+	require.NoError(t, os.WriteFile(empty, []byte("kind: Namespace\n"), 0o600))
+
+	list, err := ReteriveList([]string{"file://" + dir, "file://" + empty}, func(string) bool { return true },
+		WithOptionalSources("file://"+empty))
+
+	require.Equal(t, []string{"busybox"}, list.Images)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.NotErrorIs(t, err, ErrNoImages)
+
+	var sourceErr *SourceError
+	require.True(t, errors.As(err, &sourceErr))
+	require.Equal(t, "file://"+dir, sourceErr.Source)
+}
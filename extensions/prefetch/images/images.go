@@ -1,6 +1,6 @@
 // Copyright (c) 2021 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,17 +20,12 @@
 package images
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
-
-	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -46,159 +41,129 @@ type ImageList struct {
 // sources can be in format
 // 1. Local files: file://..
 // 2. Remote gettable content: https://raw.githubusercontent.com/...
-// 3. Remote files and dirs via github api: https://api.github.com/repos/...
-func ReteriveList(sources []string, match func(string) bool) *ImageList {
+// 3. Remote files and dirs via github api: https://api.github.com/repos/<owner>/<repo>/contents/<path>?ref=<ref>
+// 4. Local kustomization dirs, images are taken from rendered resources: kustomize://..
+// Requests to GitHub are authenticated by the token from GITHUB_TOKEN env if it is set.
+// Images of GitHub sources are cached on disk if WithCache option is passed.
+// Sources that fail or yield no images are reported as SourceError in the joined error, images of other sources are returned anyway.
+// Images of readable files of the failed source are returned too. Sources passed by WithOptionalSources may yield no images.
+func ReteriveList(sources []string, match func(string) bool, opts ...Option) (*ImageList, error) {
+	var o = &reteriveOptions{optional: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	var result = new(ImageList)
-	var filesURls []string
+	var errs []error
 
 	for _, source := range sources {
-		filesURls = append(filesURls, reteriveFileList(source, match)...)
-	}
-
-	for _, fileURL := range filesURls {
-		var content = readContent(fileURL)
-		var images = getImages(content)
-		result.Images = append(result.Images, images...)
+		var manifest = o.cache.get(source)
+		var err error
+		if manifest == nil {
+			manifest, err = reteriveSource(source, match)
+			if err == nil && len(manifest.Images) == 0 && !o.optional[source] {
+				err = ErrNoImages
+			}
+			if err == nil {
+				o.cache.put(source, manifest)
+			}
+		}
+		if err != nil {
+			errs = append(errs, &SourceError{Source: source, Err: err})
+		}
+		if manifest != nil {
+			// Note: overrides of kustomizations of the source don't apply to images of other sources
+			result.Images = append(result.Images, applyOverrides(manifest.Images, manifest.Overrides)...)
+		}
 	}
 
-	return result
+	return result, errors.Join(errs...)
 }
 
-func getImages(content []byte) []string {
-	var imagesList ImageList
-
-	_ = yaml.Unmarshal(content, &imagesList)
-
-	if len(imagesList.Images) > 0 {
-		return imagesList.Images
+// reteriveSource returns images of all files of the source. Files that can't be read are reported in the joined error,
+// images of other files are returned anyway. The manifest is nil if files of the source can't be listed.
+func reteriveSource(source string, match func(string) bool) (*Manifest, error) {
+	filesURLs, err := reteriveFileList(source, match)
+	if err != nil {
+		return nil, err
 	}
 
-	var imagePattern = regexp.MustCompile(".*image: (?P<image>.*)")
-	var imageSubexpIndex = imagePattern.SubexpIndex("image")
-	var result []string
-
-	if imagePattern.Match(content) {
-		for _, match := range imagePattern.FindAllStringSubmatch(string(content), -1) {
-			result = append(result, match[imageSubexpIndex])
+	var result = new(Manifest)
+	var errs []error
+	for _, fileURL := range filesURLs {
+		content, readErr := readContent(fileURL)
+		if readErr != nil {
+			errs = append(errs, readErr)
+			continue
 		}
+		var manifest = ParseManifest(content)
+		result.Images = append(result.Images, manifest.Images...)
+		result.Overrides = append(result.Overrides, manifest.Overrides...)
 	}
-	return result
+	return result, errors.Join(errs...)
 }
 
-func readContent(rawurl string) []byte {
-	var u, _ = url.Parse(rawurl)
-	if u.Scheme == fileScheme {
-		var p = filepath.Join(u.Hostname(), u.Path)
-		b, err := os.ReadFile(filepath.Clean(p))
-		if err == nil {
-			return b
-		}
-		return nil
+func readContent(rawurl string) ([]byte, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
 	}
-	if u.Scheme == "http" || u.Scheme == "https" {
-		// #nosec
-		resp, err := http.Get(rawurl)
-		if err != nil {
-			return nil
-		}
-		defer func() {
-			_ = resp.Body.Close()
-		}()
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil
-		}
-		return b
+	switch u.Scheme {
+	case kustomizeScheme:
+		return renderKustomization(rawurl)
+	case fileScheme:
+		return os.ReadFile(filepath.Clean(filepath.Join(u.Hostname(), u.Path)))
+	case "http", "https":
+		return httpGet(rawurl)
 	}
-
-	return nil
+	return nil, fmt.Errorf("%w: %v", ErrUnsupportedSource, rawurl)
 }
 
-func reteriveLocalFileList(rawurl string, match func(string) bool) []string {
+func reteriveLocalFileList(rawurl string, match func(string) bool) ([]string, error) {
 	u, err := url.Parse(rawurl)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
 	basePath := filepath.Join(u.Hostname(), u.Path)
 
-	root, err := os.Open(filepath.Clean(basePath))
-	if err != nil {
-		return nil
-	}
-
-	stat, err := root.Stat()
+	stat, err := os.Stat(filepath.Clean(basePath))
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
 	if !stat.IsDir() {
-		return []string{fmt.Sprintf("%v://%v", fileScheme, basePath)}
+		return []string{fmt.Sprintf("%v://%v", fileScheme, basePath)}, nil
 	}
 
 	var result []string
 
 	files, err := os.ReadDir(basePath)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
 	for _, f := range files {
 		var p = fmt.Sprintf("%v://%v", fileScheme, filepath.Join(basePath, f.Name()))
 		if f.IsDir() {
-			result = append(result, reteriveFileList(p, match)...)
+			dirFiles, dirErr := reteriveFileList(p, match)
+			if dirErr != nil {
+				return nil, dirErr
+			}
+			result = append(result, dirFiles...)
 		} else if match(f.Name()) {
 			result = append(result, p)
 		}
 	}
-	return result
+	return result, nil
 }
 
-func reteriveGithubFileList(rawurl string, match func(string) bool) []string {
-	var b = readContent(rawurl)
-
-	if b == nil {
-		return nil
-	}
-
-	var objects []map[string]interface{}
-	var result []string
-
-	if err := json.Unmarshal(b, &objects); err != nil {
-		var object map[string]interface{}
-		if err = json.Unmarshal(b, &object); err != nil {
-			return nil
-		}
-		objects = append(objects, object)
-	}
-
-	for _, obj := range objects {
-		if _, ok := obj["path"]; !ok {
-			continue
-		}
-		if _, ok := obj["type"]; !ok {
-			continue
-		}
-		var p = obj["path"].(string)
-
-		if obj["type"] == "file" {
-			if match(obj["name"].(string)) {
-				result = append(result, obj["download_url"].(string))
-			}
-		}
-
-		if obj["type"] == "dir" {
-			var nextContentsURL = apiContentsURL(rawurl, p)
-			result = append(result, reteriveFileList(nextContentsURL, match)...)
-		}
-	}
-
-	return result
-}
-
-func reteriveFileList(u string, match func(string) bool) []string {
+func reteriveFileList(u string, match func(string) bool) ([]string, error) {
 	if strings.HasPrefix(u, "https://raw.githubusercontent.com") {
-		return []string{u}
+		return []string{u}, nil
+	}
+	if strings.HasPrefix(u, kustomizeScheme+"://") {
+		return []string{u}, nil
 	}
 	if strings.HasPrefix(u, "file://") {
 		return reteriveLocalFileList(u, match)
@@ -206,15 +171,5 @@ func reteriveFileList(u string, match func(string) bool) []string {
 	if strings.HasPrefix(u, "https://api.github.com/repos/") {
 		return reteriveGithubFileList(u, match)
 	}
-	return nil
-}
-
-func apiContentsURL(contentsURL, newPath string) string {
-	var u, _ = url.Parse(contentsURL)
-	var segments = strings.Split(u.Path, string(filepath.Separator))
-
-	segments = append(segments[:5], newPath)
-	u.Path = strings.Join(segments, string(filepath.Separator))
-
-	return u.String()
+	return nil, fmt.Errorf("%w: %v", ErrUnsupportedSource, u)
 }
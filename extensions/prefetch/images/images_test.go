@@ -1,6 +1,6 @@
 // Copyright (c) 2021 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2022 Cisco and/or its affiliates.
+// Copyright (c) 2022-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -41,7 +41,7 @@ func ExampleReteriveList() {
 		"file://samples/alpine.yaml",
 	}
 
-	var list = images.ReteriveList(sources, yamlFileMatch)
+	var list, _ = images.ReteriveList(sources, yamlFileMatch)
 
 	for _, image := range list.Images {
 		fmt.Println(image)
@@ -56,8 +56,24 @@ func ExampleReteriveList() {
 	// alpine
 	// image1
 	// image2
+	// busybox
+	// busybox:1.36
+	// ghcr.io/networkservicemesh/cmd-nse-icmp-responder:v1.14.0
+	// registry.local:5000/tools/cleanup@sha256:4d5e6f
+	// alpine:3.19
+	// nicolaka/netshoot
+	// registry.local:5000/cmd-nse-icmp-responder:v1.14.1
+	// alpine@sha256:c5b1261d
 	// alpine
 	// image1
 	// image2
+	// busybox
+	// busybox:1.36
+	// ghcr.io/networkservicemesh/cmd-nse-icmp-responder:v1.14.0
+	// registry.local:5000/tools/cleanup@sha256:4d5e6f
+	// alpine:3.19
+	// nicolaka/netshoot
+	// registry.local:5000/cmd-nse-icmp-responder:v1.14.1
+	// alpine@sha256:c5b1261d
 	// alpine
 }
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const kustomizeScheme = "kustomize"
+
+// kustomizeCommand renders the kustomization dir passed as the last argument.
+var kustomizeCommand = []string{"kubectl", "kustomize"}
+
+// renderKustomization returns resources of the kustomization with all transformations, patches and components applied.
+// rawurl is in format kustomize:///path/to/kustomization/dir.
+func renderKustomization(rawurl string) ([]byte, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	var dir = filepath.Join(u.Hostname(), u.Path)
+
+	var stdout, stderr bytes.Buffer
+	// #nosec
+	cmd := exec.Command(kustomizeCommand[0], append(kustomizeCommand[1:], dir)...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to render kustomization %v: %w: %v", dir, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReteriveList_ShouldRenderKustomizations(t *testing.T) {
+	defer func(command []string) { kustomizeCommand = command }(kustomizeCommand)
+	kustomizeCommand = []string{"sh", "-c", `cat "$0/rendered.yaml"`}
+
+	var dir = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rendered.yaml"), readSample(t, "workloads.yaml"), 0o600))
+
+	list, err := ReteriveList([]string{"kustomize://" + dir}, func(string) bool { return false })
+	require.NoError(t, err)
+	require.Equal(t, ParseManifest(readSample(t, "workloads.yaml")).Images, list.Images)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import "strings"
+
+const (
+	defaultDomain = "docker.io"
+	defaultTag    = "latest"
+)
+
+// Normalize returns the fully qualified reference of the image in the form container runtimes report it,
+// e.g. alpine becomes docker.io/library/alpine:latest. References with a digest are returned without the tag.
+func Normalize(image string) string {
+	name, tag, digest := splitImage(strings.TrimSpace(image))
+
+	var segments = strings.SplitN(name, "/", 2)
+	switch {
+	case len(segments) == 1:
+		name = defaultDomain + "/library/" + name
+	case !strings.ContainsAny(segments[0], ".:") && segments[0] != "localhost":
+		name = defaultDomain + "/" + name
+	}
+
+	if digest != "" {
+		return name + "@" + digest
+	}
+	if tag == "" {
+		tag = defaultTag
+	}
+	return name + ":" + tag
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import "time"
+
+type reteriveOptions struct {
+	cache    *imageCache
+	optional map[string]bool
+}
+
+// Option is an option pattern for ReteriveList
+type Option func(o *reteriveOptions)
+
+// WithCache - keep images of GitHub sources in the dir. Sources pinned to commits never expire, sources of branches and tags expire after the ttl
+func WithCache(dir string, ttl time.Duration) Option {
+	return func(o *reteriveOptions) {
+		o.cache = &imageCache{dir: dir, ttl: ttl}
+	}
+}
+
+// WithOptionalSources - sources that may yield no images, e.g. resources applied by steps which have no workloads. ErrNoImages is not reported for them
+func WithOptionalSources(sources ...string) Option {
+	return func(o *reteriveOptions) {
+		for _, source := range sources {
+			o.optional[source] = true
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// imageLineRegex is used for documents that are not valid YAML, e.g. templates. Commented out lines are skipped.
+var imageLineRegex = regexp.MustCompile(`(?m)^[ \t]*(?:-[ \t]*)?image:[ \t]*([^#\n]*)`)
+
+// documentSeparatorRegex splits files into YAML documents.
+var documentSeparatorRegex = regexp.MustCompile(`(?m)^---[ \t]*(?:#.*)?$`)
+
+// podSpecPaths are paths to pod specs of workload kinds.
+var podSpecPaths = map[string][]string{
+	"Pod":                   {"spec"},
+	"PodTemplate":           {"template", "spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+var containerFields = []string{"initContainers", "containers", "ephemeralContainers"}
+
+// Override is an image override of kustomization.
+type Override struct {
+	Name    string `yaml:"name"`
+	NewName string `yaml:"newName"`
+	NewTag  string `yaml:"newTag"`
+	Digest  string `yaml:"digest"`
+}
+
+// Apply returns the image with the override applied. ok is false if the override doesn't match the image.
+func (o *Override) Apply(image string) (result string, ok bool) {
+	name, tag, digest := splitImage(image)
+	if name != o.Name {
+		return image, false
+	}
+	if o.NewName != "" {
+		name = o.NewName
+	}
+	if o.NewTag != "" {
+		tag = o.NewTag
+	}
+	if o.Digest != "" {
+		tag, digest = "", o.Digest
+	}
+	return joinImage(name, tag, digest), true
+}
+
+// Manifest is a content of the file with images.
+type Manifest struct {
+	Images    []string
+	Overrides []Override
+}
+
+// ParseManifest parses images of all documents of the file. Documents can be lists of images, kustomizations
+// with image overrides or Kubernetes objects, images of workloads are taken from their pod templates.
+// Images of documents that are not valid YAML are taken from their image lines.
+func ParseManifest(content []byte) *Manifest {
+	var result = new(Manifest)
+	for _, document := range documentSeparatorRegex.Split(string(content), -1) {
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal([]byte(document), &doc); err != nil {
+			result.Images = append(result.Images, imagesOfLines([]byte(document))...)
+			continue
+		}
+		result.add(doc)
+	}
+	return result
+}
+
+func (m *Manifest) add(doc map[string]interface{}) {
+	if items, ok := doc["images"].([]interface{}); ok {
+		for _, item := range items {
+			switch v := item.(type) {
+			case string:
+				m.Images = append(m.Images, v)
+			case map[string]interface{}:
+				m.Overrides = append(m.Overrides, Override{
+					Name:    stringOf(v["name"]),
+					NewName: stringOf(v["newName"]),
+					NewTag:  stringOf(v["newTag"]),
+					Digest:  stringOf(v["digest"]),
+				})
+			}
+		}
+	}
+
+	kind, _ := doc["kind"].(string)
+	if kind == "List" {
+		items, _ := doc["items"].([]interface{})
+		for _, item := range items {
+			if obj, ok := item.(map[string]interface{}); ok {
+				m.add(obj)
+			}
+		}
+		return
+	}
+
+	path, ok := podSpecPaths[kind]
+	if !ok {
+		return
+	}
+	var spec interface{} = doc
+	for _, field := range path {
+		obj, isMap := spec.(map[string]interface{})
+		if !isMap {
+			return
+		}
+		spec = obj[field]
+	}
+	podSpec, _ := spec.(map[string]interface{})
+	for _, field := range containerFields {
+		containers, _ := podSpec[field].([]interface{})
+		for _, c := range containers {
+			container, _ := c.(map[string]interface{})
+			if image := stringOf(container["image"]); image != "" {
+				m.Images = append(m.Images, image)
+			}
+		}
+	}
+}
+
+func imagesOfLines(content []byte) []string {
+	var result []string
+	for _, match := range imageLineRegex.FindAllSubmatch(content, -1) {
+		var image = strings.Trim(strings.TrimSpace(string(match[1])), `"'`)
+		// Note: templated images can't be resolved
+		if image != "" && !strings.ContainsAny(image, "{} ") {
+			result = append(result, image)
+		}
+	}
+	return result
+}
+
+// applyOverrides returns images with overridden images added. Images of overrides that don't match any image are added too.
+func applyOverrides(images []string, overrides []Override) []string {
+	var result = append([]string(nil), images...)
+	for i := range overrides {
+		var added = make(map[string]bool)
+		var matched bool
+		for _, image := range images {
+			if overridden, ok := overrides[i].Apply(image); ok {
+				matched = true
+				if !added[overridden] {
+					added[overridden] = true
+					result = append(result, overridden)
+				}
+			}
+		}
+		if !matched && (overrides[i].NewTag != "" || overrides[i].Digest != "") {
+			overridden, _ := overrides[i].Apply(overrides[i].Name)
+			result = append(result, overridden)
+		}
+	}
+	return result
+}
+
+func splitImage(image string) (name, tag, digest string) {
+	name = image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, digest = name[:i], name[i+1:]
+	}
+	// Note: a colon before the last slash belongs to the registry port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	return name, tag, digest
+}
+
+func joinImage(name, tag, digest string) string {
+	if tag != "" {
+		name += ":" + tag
+	}
+	if digest != "" {
+		name += "@" + digest
+	}
+	return name
+}
+
+func stringOf(v interface{}) string {
+	s, _ := v.(string)
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readSample(t *testing.T, name string) []byte {
+	content, err := os.ReadFile("samples/" + name)
+	require.NoError(t, err)
+	return content
+}
+
+func Test_ParseManifest_ShouldWalkPodTemplates(t *testing.T) {
+	var manifest = ParseManifest(readSample(t, "workloads.yaml"))
+
+	require.Equal(t, []string{
+		"busybox:1.36",
+		"ghcr.io/networkservicemesh/cmd-nse-icmp-responder:v1.14.0",
+		"registry.local:5000/tools/cleanup@sha256:4d5e6f",
+		"alpine:3.19",
+		"nicolaka/netshoot",
+	}, manifest.Images)
+	require.Empty(t, manifest.Overrides)
+}
+
+func Test_ParseManifest_ShouldReadImageLists(t *testing.T) {
+	require.Equal(t, []string{"image1", "image2"}, ParseManifest(readSample(t, "prefetch.yaml")).Images)
+	require.Equal(t, []string{"alpine"}, ParseManifest(readSample(t, "alpine.yaml")).Images)
+}
+
+func Test_ParseManifest_ShouldSkipCommentsOfInvalidYAML(t *testing.T) {
+	require.Equal(t, []string{"busybox"}, ParseManifest(readSample(t, "template.yaml")).Images)
+}
+
+func Test_ParseManifest_ShouldParseLinesOnlyOfInvalidDocuments(t *testing.T) {
+	var content = append(readSample(t, "template.yaml"), readSample(t, "kustomization.yaml")...)
+	content = append(content, "\n---\n# image: commented-out\nimages:\n  - alpine:3.19\n"...)
+
+	var manifest = ParseManifest(content)
+	require.Equal(t, []string{"busybox", "alpine:3.19"}, manifest.Images)
+	require.Len(t, manifest.Overrides, 2)
+}
+
+func Test_ParseManifest_ShouldApplyKustomizationOverrides(t *testing.T) {
+	var manifest = ParseManifest(readSample(t, "kustomization.yaml"))
+	require.Empty(t, manifest.Images)
+	require.Len(t, manifest.Overrides, 2)
+
+	var images = ParseManifest(readSample(t, "workloads.yaml")).Images
+	require.Equal(t, append(images,
+		"registry.local:5000/cmd-nse-icmp-responder:v1.14.1",
+		"alpine@sha256:c5b1261d",
+	), applyOverrides(images, manifest.Overrides))
+
+	// Note: overrides of the kustomization don't apply to images of another source
+	var kustomizationDir, otherDir = t.TempDir(), t.TempDir()
+	for _, name := range []string{"kustomization.yaml", "workloads.yaml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(kustomizationDir, name), readSample(t, name), 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "images.yaml"), []byte("images:\n  - alpine\n"), 0o600))
+
+	list, err := ReteriveList([]string{"file://" + otherDir, "file://" + kustomizationDir}, func(s string) bool {
+		return strings.HasSuffix(s, ".yaml")
+	})
+	require.NoError(t, err)
+	require.Equal(t, append([]string{"alpine"}, applyOverrides(images, manifest.Overrides)...), list.Images)
+}
+
+func Test_Normalize_ShouldQualifyReferences(t *testing.T) {
+	for image, expected := range map[string]string{
+		"alpine":                       "docker.io/library/alpine:latest",
+		"alpine:3.19":                  "docker.io/library/alpine:3.19",
+		"nicolaka/netshoot":            "docker.io/nicolaka/netshoot:latest",
+		"ghcr.io/nsm/cmd-nsc:v1.0.0":   "ghcr.io/nsm/cmd-nsc:v1.0.0",
+		"registry.local:5000/cleanup":  "registry.local:5000/cleanup:latest",
+		"localhost/cleanup:v1":         "localhost/cleanup:v1",
+		"alpine:3.19@sha256:c5b1261d":  "docker.io/library/alpine@sha256:c5b1261d",
+		"docker.io/library/alpine:3.1": "docker.io/library/alpine:3.1",
+	} {
+		require.Equal(t, expected, Normalize(image), image)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/integration-tests/extensions/prefetch/images"
+)
+
+// inventory is a set of images present on each node of the cluster. Images are normalized by images.Normalize.
+type inventory map[string]map[string]bool
+
+func (inv inventory) add(node string, names ...string) {
+	if inv[node] == nil {
+		inv[node] = make(map[string]bool)
+	}
+	for _, name := range names {
+		inv[node][images.Normalize(name)] = true
+	}
+}
+
+func (inv inventory) has(node, image string) bool {
+	return inv[node][images.Normalize(image)]
+}
+
+// missing returns images that are missing on at least one node. All images are missing if nodes are unknown.
+func (inv inventory) missing(imgs []string) []string {
+	if len(inv) == 0 {
+		return imgs
+	}
+	var result []string
+	for _, image := range imgs {
+		for node := range inv {
+			if !inv.has(node, image) {
+				result = append(result, image)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// report logs numbers of images that are already cached on each node and images that are pulled.
+func (inv inventory) report(cluster string, imgs []string) {
+	var nodes = make([]string, 0, len(inv))
+	for node := range inv {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	for _, node := range nodes {
+		var cached int
+		for _, image := range imgs {
+			if inv.has(node, image) {
+				cached++
+			}
+		}
+		logrus.WithFields(logrus.Fields{
+			"cluster": cluster,
+			"node":    node,
+			"cached":  cached,
+			"pulled":  len(imgs) - cached,
+		}).Info("Images of the node")
+	}
+}
+
+// nodeInventory returns images that kubelets report in status.images of Node objects.
+// Note: kubelets report up to 50 images by default, images out of the report are prefetched anyway.
+func nodeInventory(ctx context.Context, run func(ctx context.Context, cmd string) (string, error), kubeConfig string) (inventory, error) {
+	out, err := run(ctx, fmt.Sprintf("kubectl --kubeconfig %v get nodes -o json", kubeConfig))
+	if err != nil {
+		return nil, err
+	}
+	var nodes struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Status struct {
+				Images []struct {
+					Names []string `json:"names"`
+				} `json:"images"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err = json.Unmarshal([]byte(out), &nodes); err != nil {
+		return nil, err
+	}
+
+	var result = make(inventory)
+	for _, node := range nodes.Items {
+		result.add(node.Metadata.Name)
+		for _, image := range node.Status.Images {
+			result.add(node.Metadata.Name, image.Names...)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const nodesJSON = `{"items": [
+	{"metadata": {"name": "worker-1"}, "status": {"images": [
+		{"names": ["docker.io/library/alpine@sha256:c5b1261d", "docker.io/library/alpine:3.19"]},
+		{"names": ["ghcr.io/networkservicemesh/cmd-nsc:v1.0.0"]}
+	]}},
+	{"metadata": {"name": "worker-2"}, "status": {"images": [
+		{"names": ["docker.io/library/alpine:3.19"]}
+	]}}
+]}`
+
+func Test_NodeInventory_ShouldReturnImagesMissingSomewhere(t *testing.T) {
+	inv, err := nodeInventory(context.Background(), func(_ context.Context, cmd string) (string, error) {
+		require.Equal(t, "kubectl --kubeconfig /kubeconfig get nodes -o json", cmd)
+		return nodesJSON, nil
+	}, "/kubeconfig")
+	require.NoError(t, err)
+
+	require.True(t, inv.has("worker-1", "alpine@sha256:c5b1261d"))
+	require.False(t, inv.has("worker-2", "alpine@sha256:c5b1261d"))
+	require.Equal(t, []string{
+		"ghcr.io/networkservicemesh/cmd-nsc:v1.0.0",
+		"nicolaka/netshoot",
+	}, inv.missing([]string{"alpine:3.19", "ghcr.io/networkservicemesh/cmd-nsc:v1.0.0", "nicolaka/netshoot"}))
+}
+
+func Test_Inventory_ShouldReturnAllImagesIfNodesAreUnknown(t *testing.T) {
+	var inv inventory
+	require.Equal(t, []string{"alpine"}, inv.missing([]string{"alpine"}))
+	require.False(t, inv.has("worker-1", "alpine"))
+}
@@ -1,6 +1,6 @@
 // Copyright (c) 2021 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2024 Cisco and/or its affiliates.
+// Copyright (c) 2024-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -23,6 +23,9 @@ import (
 	"text/template"
 )
 
+// createDaemonSet returns the command writing the DaemonSet with the prefetch containers. Prefetch containers are init containers:
+// images may have no long running binary, so containers exit once their images are pulled. Init containers start one by one,
+// so a failing image blocks pulls of next images of the pod, the pull watcher reports them as not attempted.
 func createDaemonSet(number int, containers string) string {
 	const text = `
 cat > prefetch-{{.Number}}.yaml <<EOF
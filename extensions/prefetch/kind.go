@@ -0,0 +1,224 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	daemonSetBackend = "daemonset"
+	kindBackend      = "kind"
+	// kindClusterLabel is a label of node containers created by kind.
+	kindClusterLabel = "io.x-k8s.kind.cluster"
+	// defaultSnapshotter is the snapshotter of kind nodes which containerd config doesn't set it.
+	defaultSnapshotter = "overlayfs"
+)
+
+// snapshotterRegex finds the snapshotter in the containerd config of a node. Snapshotters of runtimes are usually empty.
+var snapshotterRegex = regexp.MustCompile(`(?m)^\s*snapshotter\s*=\s*"([^"]+)"`)
+
+// kindLoader pulls images once on the host and loads them into node containers of kind clusters.
+type kindLoader struct {
+	cli string
+	run func(ctx context.Context, cmd string) (string, error)
+
+	mu           sync.Mutex
+	pulls        map[string]*pull
+	snapshotters map[string]string
+}
+
+type pull struct {
+	once sync.Once
+	err  error
+}
+
+func newKindLoader(cli string) *kindLoader {
+	return &kindLoader{cli: cli, run: runShell, pulls: make(map[string]*pull), snapshotters: make(map[string]string)}
+}
+
+// Nodes returns node containers of the cluster. Node names of kind clusters match names of their containers.
+func (l *kindLoader) Nodes(ctx context.Context, kubeConfig string) ([]string, error) {
+	containers, err := l.run(ctx, fmt.Sprintf("%v ps --filter label=%v --format '{{.Names}}'", l.cli, kindClusterLabel))
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := l.run(ctx, fmt.Sprintf("kubectl --kubeconfig %v get nodes -o jsonpath='{.items[*].metadata.name}'", kubeConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	var isContainer = make(map[string]bool)
+	for _, c := range strings.Fields(containers) {
+		isContainer[c] = true
+	}
+	var result []string
+	for _, node := range strings.Fields(nodes) {
+		if !isContainer[node] {
+			return nil, fmt.Errorf("node %v is not a kind container", node)
+		}
+		result = append(result, node)
+	}
+	return result, nil
+}
+
+// Inventory returns images of the node containers reported by crictl. Unlike status.images of Node objects, it isn't truncated.
+func (l *kindLoader) Inventory(ctx context.Context, nodes []string) (inventory, error) {
+	var result = make(inventory)
+	for _, node := range nodes {
+		out, err := l.run(ctx, fmt.Sprintf("%v exec %v crictl images -o json", l.cli, node))
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Images []struct {
+				RepoTags    []string `json:"repoTags"`
+				RepoDigests []string `json:"repoDigests"`
+			} `json:"images"`
+		}
+		if err = json.Unmarshal([]byte(out), &list); err != nil {
+			return nil, fmt.Errorf("unexpected crictl output of %v: %w", node, err)
+		}
+		result.add(node)
+		for _, image := range list.Images {
+			result.add(node, append(image.RepoTags, image.RepoDigests...)...)
+		}
+	}
+	return result, nil
+}
+
+// Load loads images into the nodes that don't have them. Each image is pulled on the host once for all clusters.
+// Images are imported the way kind load does: all platforms into the snapshotter of the node.
+func (l *kindLoader) Load(ctx context.Context, nodes, images []string, inv inventory, status *clusterStatus) error {
+	for _, image := range images {
+		var targets []string
+		for _, node := range nodes {
+			if !inv.has(node, image) {
+				targets = append(targets, node)
+			}
+		}
+		if len(targets) == 0 {
+			atomic.AddInt32(&status.Done, 1)
+			continue
+		}
+		if err := l.pull(ctx, image); err != nil {
+			return err
+		}
+		archive, err := l.save(ctx, image)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		var errs = make([]error, len(targets))
+		for i, node := range targets {
+			wg.Add(1)
+			go func(i int, node string) {
+				defer wg.Done()
+				snapshotter, snapshotterErr := l.snapshotter(ctx, node)
+				if snapshotterErr != nil {
+					errs[i] = snapshotterErr
+					return
+				}
+				_, errs[i] = l.run(ctx, fmt.Sprintf("%v exec -i %v ctr --namespace=k8s.io images import --all-platforms --digests --snapshotter=%v - < %v",
+					l.cli, node, snapshotter, archive))
+			}(i, node)
+		}
+		wg.Wait()
+		_ = os.Remove(archive)
+		for i, err := range errs {
+			if err != nil {
+				return fmt.Errorf("can't load %v into %v: %w", image, targets[i], err)
+			}
+		}
+		atomic.AddInt32(&status.Done, 1)
+	}
+	return nil
+}
+
+func (l *kindLoader) pull(ctx context.Context, image string) error {
+	l.mu.Lock()
+	p, ok := l.pulls[image]
+	if !ok {
+		p = new(pull)
+		l.pulls[image] = p
+	}
+	l.mu.Unlock()
+
+	p.once.Do(func() {
+		_, p.err = l.run(ctx, fmt.Sprintf("%v image inspect %v > /dev/null 2>&1 || %v pull %v", l.cli, image, l.cli, image))
+	})
+	return p.err
+}
+
+// save saves the image into a temporary archive. The archive is imported separately, so a failed save isn't hidden by a pipe.
+func (l *kindLoader) save(ctx context.Context, image string) (string, error) {
+	f, err := os.CreateTemp("", "prefetch-*.tar")
+	if err != nil {
+		return "", err
+	}
+	_ = f.Close()
+	if _, err = l.run(ctx, fmt.Sprintf("%v save -o %v %v", l.cli, f.Name(), image)); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// snapshotter returns the snapshotter of containerd of the node.
+func (l *kindLoader) snapshotter(ctx context.Context, node string) (string, error) {
+	l.mu.Lock()
+	result, ok := l.snapshotters[node]
+	l.mu.Unlock()
+	if ok {
+		return result, nil
+	}
+
+	out, err := l.run(ctx, fmt.Sprintf("%v exec %v containerd config dump", l.cli, node))
+	if err != nil {
+		return "", err
+	}
+	result = defaultSnapshotter
+	if m := snapshotterRegex.FindStringSubmatch(out); m != nil {
+		result = m[1]
+	}
+
+	l.mu.Lock()
+	l.snapshotters[node] = result
+	l.mu.Unlock()
+	return result, nil
+}
+
+func runShell(ctx context.Context, cmd string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	// #nosec
+	c := exec.CommandContext(ctx, "sh", "-c", cmd)
+	c.Stdout, c.Stderr = &stdout, &stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("%v: %w: %v", cmd, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_KindLoader_ShouldPullImagesOnce(t *testing.T) {
+	var mu sync.Mutex
+	var commands []string
+	var l = newKindLoader("docker")
+	l.run = func(_ context.Context, cmd string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		commands = append(commands, cmd)
+		switch {
+		case strings.HasPrefix(cmd, "docker ps"):
+			return "kind-control-plane\nkind-worker\nkind-2-control-plane\n", nil
+		case strings.Contains(cmd, "get nodes"):
+			return "kind-control-plane kind-worker", nil
+		}
+		return "", nil
+	}
+
+	nodes, err := l.Nodes(context.Background(), "/kubeconfig")
+	require.NoError(t, err)
+	require.Equal(t, []string{"kind-control-plane", "kind-worker"}, nodes)
+
+	for i := 0; i < 2; i++ {
+		var status = new(clusterStatus)
+		require.NoError(t, l.Load(context.Background(), nodes, []string{"alpine:3.19"}, nil, status))
+		require.Equal(t, int32(1), status.Done)
+	}
+
+	var pulls, saves, loads int
+	for _, cmd := range commands {
+		if strings.Contains(cmd, "docker pull alpine:3.19") {
+			pulls++
+		}
+		if strings.HasPrefix(cmd, "docker save -o ") && strings.HasSuffix(cmd, " alpine:3.19") {
+			saves++
+		}
+		if strings.HasPrefix(cmd, "docker exec -i kind-") {
+			require.Contains(t, cmd, " ctr --namespace=k8s.io images import --all-platforms --digests --snapshotter=overlayfs - < ")
+			loads++
+		}
+	}
+	require.Equal(t, 1, pulls)
+	require.Equal(t, 2, saves)
+	require.Equal(t, 4, loads)
+}
+
+func Test_KindLoader_ShouldFailIfSaveFails(t *testing.T) {
+	var l = newKindLoader("docker")
+	l.run = func(_ context.Context, cmd string) (string, error) {
+		if strings.HasPrefix(cmd, "docker save") {
+			return "", errors.New("exit status 1")
+		}
+		return "", nil
+	}
+
+	var status = new(clusterStatus)
+	require.Error(t, l.Load(context.Background(), []string{"kind-control-plane"}, []string{"alpine:3.19"}, nil, status))
+	require.Equal(t, int32(0), status.Done)
+}
+
+func Test_KindLoader_ShouldRejectNonKindNodes(t *testing.T) {
+	var l = newKindLoader("docker")
+	l.run = func(_ context.Context, cmd string) (string, error) {
+		if strings.Contains(cmd, "get nodes") {
+			return "worker-1", nil
+		}
+		return "", nil
+	}
+
+	_, err := l.Nodes(context.Background(), "/kubeconfig")
+	require.Error(t, err)
+}
+
+func Test_KindLoader_ShouldSkipNodesWithImages(t *testing.T) {
+	var commands []string
+	var l = newKindLoader("docker")
+	l.run = func(_ context.Context, cmd string) (string, error) {
+		commands = append(commands, cmd)
+		if cmd == "docker exec kind-worker crictl images -o json" {
+			return `{"images":[{"repoTags":["docker.io/library/alpine:3.19"],"repoDigests":["docker.io/library/alpine@sha256:c5b1261d"]}]}`, nil
+		}
+		if strings.Contains(cmd, "crictl images") {
+			return `{"images":[]}`, nil
+		}
+		if strings.HasSuffix(cmd, "containerd config dump") {
+			return "[plugins.\"io.containerd.grpc.v1.cri\".containerd]\n  snapshotter = \"native\"\n", nil
+		}
+		return "", nil
+	}
+
+	var nodes = []string{"kind-control-plane", "kind-worker"}
+	inv, err := l.Inventory(context.Background(), nodes)
+	require.NoError(t, err)
+	require.Equal(t, []string{"alpine:3.19"}, inv.missing([]string{"alpine:3.19"}))
+
+	commands = nil
+	require.NoError(t, l.Load(context.Background(), nodes, []string{"alpine:3.19"}, inv, new(clusterStatus)))
+	var loads int
+	for _, cmd := range commands {
+		require.NotContains(t, cmd, "-i kind-worker")
+		if strings.HasPrefix(cmd, "docker exec -i kind-control-plane ctr --namespace=k8s.io images import --all-platforms --digests --snapshotter=native - < ") {
+			loads++
+		}
+	}
+	require.Equal(t, 1, loads)
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GeneratedSuiteFile is a name of files with suites generated by gotestmd.
+const GeneratedSuiteFile = "suite.gen.go"
+
+var (
+	runnerRegex = regexp.MustCompile(`\.Runner\("([^"]*)"`)
+	applyRegex  = regexp.MustCompile("kubectl[^\n`]*?\\sapply\\s+(-k|-f)\\s+([^\\s`\"]+)")
+	importRegex = regexp.MustCompile(`"([^"\s]+)"`)
+)
+
+// Checkout is a local checkout of the repository that steps of generated suites deploy from.
+type Checkout struct {
+	// Root is a root of the module with generated suites. Runner dirs are relative to it.
+	Root string
+	// RepositoryURL is a URL of the repository used in kubectl apply targets.
+	RepositoryURL string
+	// Dir is a dir of the local checkout of the repository.
+	Dir string
+}
+
+// rawURL returns the URL of raw files of the repository if it is hosted on GitHub.
+func (c *Checkout) rawURL() string {
+	const github = "https://github.com/"
+	if !strings.HasPrefix(c.RepositoryURL, github) {
+		return ""
+	}
+	return "https://raw.githubusercontent.com/" + strings.TrimPrefix(c.RepositoryURL, github)
+}
+
+// ModuleRoot returns the root of the module that has the dir.
+func ModuleRoot(dir string) (string, error) {
+	for d := dir; d != filepath.Dir(d); d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("go.mod is not found for %v", dir)
+}
+
+// SuiteFiles returns generated suite files of the suite from the dir and of all its parent suites.
+// Parent suites are generated suites of the module imported by the suite file.
+func SuiteFiles(dir, root string) ([]string, error) {
+	modulePath, err := modulePath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	var visited = make(map[string]bool)
+	var visit func(dir string) error
+	visit = func(dir string) error {
+		var file = filepath.Join(dir, GeneratedSuiteFile)
+		if visited[file] {
+			return nil
+		}
+		visited[file] = true
+
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return err
+		}
+		result = append(result, file)
+
+		for _, pkg := range suiteImports(string(content), modulePath) {
+			if err := visit(filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(pkg, modulePath+"/")))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(dir); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// suiteImports returns packages of generated suites of the module imported by the suite source.
+func suiteImports(source, modulePath string) []string {
+	var start = strings.Index(source, "import (")
+	if start < 0 {
+		return nil
+	}
+	var end = strings.Index(source[start:], ")")
+	if end < 0 {
+		return nil
+	}
+	var result []string
+	for _, m := range importRegex.FindAllStringSubmatch(source[start:start+end], -1) {
+		if strings.HasPrefix(m[1], modulePath+"/suites/") {
+			result = append(result, m[1])
+		}
+	}
+	return result
+}
+
+func modulePath(root string) (string, error) {
+	f, err := os.Open(filepath.Clean(filepath.Join(root, "go.mod")))
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var scanner = bufio.NewScanner(f)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 && fields[0] == "module" {
+			return fields[1], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("module path is not found in %v", root)
+}
+
+// StepSources returns image sources of resources applied by steps of the generated suite files.
+// kubectl apply -k targets are returned as kustomize:// sources, local kubectl apply -f targets as file:// sources.
+// Remote targets of the repository, including its raw files, are mapped to the local checkout, relative targets are resolved
+// from the dir of the runner.
+func StepSources(files []string, checkout Checkout) ([]string, error) {
+	var result = make(map[string]bool)
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range suiteSources(string(content), checkout) {
+			if _, statErr := os.Stat(strings.SplitN(source, "://", 2)[1]); statErr == nil {
+				result[source] = true
+			}
+		}
+	}
+
+	var sources = make([]string, 0, len(result))
+	for source := range result {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources, nil
+}
+
+// suiteSources returns sources of resources applied by steps of the generated suite source.
+func suiteSources(source string, checkout Checkout) []string {
+	type match struct {
+		pos    int
+		runner bool
+		flag   string
+		value  string
+	}
+	var matches []match
+	for _, m := range runnerRegex.FindAllStringSubmatchIndex(source, -1) {
+		matches = append(matches, match{pos: m[0], runner: true, value: source[m[2]:m[3]]})
+	}
+	for _, m := range applyRegex.FindAllStringSubmatchIndex(source, -1) {
+		matches = append(matches, match{pos: m[0], flag: source[m[2]:m[3]], value: source[m[4]:m[5]]})
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].pos < matches[j].pos })
+
+	var result []string
+	var runnerDir = checkout.Root
+	for _, m := range matches {
+		if m.runner {
+			runnerDir = m.value
+			if !filepath.IsAbs(runnerDir) {
+				runnerDir = filepath.Join(checkout.Root, runnerDir)
+			}
+			continue
+		}
+		var p = targetPath(m.value, runnerDir, checkout)
+		if p == "" || IsExcluded(p) {
+			continue
+		}
+		if m.flag == "-k" {
+			result = append(result, "kustomize://"+p)
+		} else {
+			result = append(result, "file://"+p)
+		}
+	}
+	return result
+}
+
+// targetPath returns the local path of the kubectl apply target. Returns empty string for unsupported targets.
+func targetPath(target, runnerDir string, checkout Checkout) string {
+	if strings.Contains(target, "$") || target == "-" {
+		return ""
+	}
+	if strings.HasPrefix(target, checkout.RepositoryURL+"/") {
+		target = strings.TrimPrefix(target, checkout.RepositoryURL+"/")
+		if i := strings.Index(target, "?"); i >= 0 {
+			target = target[:i]
+		}
+		return filepath.Join(checkout.Dir, target)
+	}
+	// Note: raw files of the repository have the ref as the first path element, e.g. <raw url>/<sha>/examples/spire/base/file.yaml
+	if rawURL := checkout.rawURL(); rawURL != "" && strings.HasPrefix(target, rawURL+"/") {
+		var parts = strings.SplitN(strings.TrimPrefix(target, rawURL+"/"), "/", 2)
+		if len(parts) != 2 {
+			return ""
+		}
+		return filepath.Join(checkout.Dir, parts[1])
+	}
+	if strings.Contains(target, "://") {
+		return ""
+	}
+	return filepath.Join(runnerDir, target)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const generatedSuite = "package multiservicemesh\n" +
+	"import (\n" +
+	"	\"github.com/networkservicemesh/integration-tests/extensions/base\"\n" +
+	"	\"github.com/networkservicemesh/integration-tests/suites/spire/single_cluster\"\n" +
+	")\n" +
+	"func (s *Suite) SetupSuite() {\n" +
+	"	r := s.Runner(\"../deployments-k8s/examples/basic\")\n" +
+	"	r.Run(`kubectl apply -k https://github.com/networkservicemesh/deployments-k8s/examples/basic?ref=c91be29099fab1f8376d9ff90c858efd829de35e`)\n" +
+	"}\n" +
+	"func (s *Suite) TestMultiServiceMesh() {\n" +
+	"	r := s.Runner(\"../deployments-k8s/examples/multiservicemesh\")\n" +
+	"	r.Run(`kubectl --kubeconfig=$KUBECONFIG1 apply -k .`)\n" +
+	"	r.Run(`kubectl apply -k ./nse` + \"\\n\" + `kubectl apply -k $DIR`)\n" +
+	"	r.Run(`kubectl apply -f client.yaml`)\n" +
+	"	r.Run(`kubectl apply -f https://raw.githubusercontent.com/networkservicemesh/deployments-k8s/c91be290/examples/spire/base/template.yaml`)\n" +
+	"	r.Run(`kubectl apply -f https://raw.githubusercontent.com/metallb/metallb/v0.12.1/manifests/metallb.yaml`)\n" +
+	"	r.Run(`kubectl apply -k https://github.com/networkservicemesh/deployments-k8s/examples/sriov?ref=c91be290`)\n" +
+	"	r.Run(`kubectl apply -k https://github.com/networkservicemesh/deployments-k8s/examples/missing?ref=c91be290`)\n" +
+	"}\n"
+
+const parentSuite = "package single_cluster\n" +
+	"import (\n" +
+	"	\"github.com/networkservicemesh/integration-tests/extensions/base\"\n" +
+	")\n" +
+	"func (s *Suite) SetupSuite() {\n" +
+	"	r := s.Runner(\"../deployments-k8s/examples/spire/single_cluster\")\n" +
+	"	r.Run(`kubectl apply -k https://github.com/networkservicemesh/deployments-k8s/examples/spire/single_cluster?ref=c91be290`)\n" +
+	"}\n"
+
+func writeFile(t *testing.T, p, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o750))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+}
+
+func Test_StepSources_ShouldCoverSuiteAndParents(t *testing.T) {
+	var root = filepath.Join(t.TempDir(), "integration-tests")
+	var checkout = Checkout{
+		Root:          root,
+		RepositoryURL: "https://github.com/networkservicemesh/deployments-k8s",
+		Dir:           filepath.Join(filepath.Dir(root), "deployments-k8s"),
+	}
+	for _, dir := range []string{"examples/basic", "examples/multiservicemesh/nse", "examples/sriov", "examples/spire/single_cluster", "examples/unused"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(checkout.Dir, dir), 0o750))
+	}
+	writeFile(t, filepath.Join(checkout.Dir, "examples/multiservicemesh/client.yaml"), "")
+	writeFile(t, filepath.Join(checkout.Dir, "examples/spire/base/template.yaml"), "")
+	writeFile(t, filepath.Join(root, "go.mod"), "module github.com/networkservicemesh/integration-tests\n\ngo 1.23\n")
+	writeFile(t, filepath.Join(root, "suites", "multiservicemesh", GeneratedSuiteFile), generatedSuite)
+	writeFile(t, filepath.Join(root, "suites", "spire", "single_cluster", GeneratedSuiteFile), parentSuite)
+	writeFile(t, filepath.Join(root, "suites", "unused", GeneratedSuiteFile),
+		"package unused\nfunc (s *Suite) SetupSuite() {\n\tr := s.Runner(\"../deployments-k8s/examples/unused\")\n\tr.Run(`kubectl apply -k .`)\n}\n")
+
+	files, err := SuiteFiles(filepath.Join(root, "suites", "multiservicemesh"), root)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(root, "suites", "multiservicemesh", GeneratedSuiteFile),
+		filepath.Join(root, "suites", "spire", "single_cluster", GeneratedSuiteFile),
+	}, files)
+
+	sources, err := StepSources(files, checkout)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"file://" + filepath.Join(checkout.Dir, "examples/multiservicemesh/client.yaml"),
+		"file://" + filepath.Join(checkout.Dir, "examples/spire/base/template.yaml"),
+		"kustomize://" + filepath.Join(checkout.Dir, "examples/basic"),
+		"kustomize://" + filepath.Join(checkout.Dir, "examples/multiservicemesh"),
+		"kustomize://" + filepath.Join(checkout.Dir, "examples/multiservicemesh/nse"),
+		"kustomize://" + filepath.Join(checkout.Dir, "examples/spire/single_cluster"),
+	}, sources)
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxContainerName is a max length of container names without the hash suffix. Container names are DNS labels of up to 63 characters.
+const maxContainerName = 55
+
+var (
+	invalidNameCharsRegex = regexp.MustCompile(`[^a-z0-9]+`)
+	// pullFailureReasons are waiting reasons of containers that can't get their images.
+	pullFailureReasons = map[string]bool{
+		"ErrImagePull":        true,
+		"ImagePullBackOff":    true,
+		"InvalidImageName":    true,
+		"ErrImageNeverPull":   true,
+		"RegistryUnavailable": true,
+	}
+)
+
+// containerName returns a readable container name of the image, e.g. cmd-nsc-v1-0-0-1a2b3c for ghcr.io/networkservicemesh/cmd-nsc:v1.0.0.
+// The hash of the full reference keeps names of images with the same name and tag from different registries unique.
+func containerName(image string) string {
+	var name = strings.Trim(invalidNameCharsRegex.ReplaceAllString(strings.ToLower(path.Base(image)), "-"), "-")
+	if len(name) > maxContainerName {
+		name = strings.TrimRight(name[:maxContainerName], "-")
+	}
+	var sum = sha256.Sum256([]byte(image))
+	return strings.TrimPrefix(name+"-"+hex.EncodeToString(sum[:])[:6], "-")
+}
+
+// pullFailure is an image that a node can't pull.
+type pullFailure struct {
+	DaemonSet string
+	Node      string
+	Image     string
+	Container string
+	Reason    string
+	Message   string
+	// NotAttempted are images of next prefetch containers of the pod. Prefetch containers are init containers
+	// which start one by one, so images after the failing one are not pulled and their failures are unknown.
+	NotAttempted []string
+}
+
+// pullWatcher polls statuses of prefetch pods of the cluster and tracks images failing to pull.
+type pullWatcher struct {
+	cluster    string
+	kubeConfig string
+	// images are images of prefetch containers by container names.
+	images map[string]string
+	run    func(ctx context.Context, cmd string) (string, error)
+
+	mu      sync.Mutex
+	failing map[string]*pullFailure
+}
+
+func newPullWatcher(cluster, kubeConfig string, images map[string]string) *pullWatcher {
+	return &pullWatcher{
+		cluster:    cluster,
+		kubeConfig: kubeConfig,
+		images:     images,
+		run:        runShell,
+		failing:    make(map[string]*pullFailure),
+	}
+}
+
+// watch polls pods until the context is done.
+func (w *pullWatcher) watch(ctx context.Context, interval time.Duration) {
+	var ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		w.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll updates failures by current statuses of containers. New failures are logged once.
+func (w *pullWatcher) poll(ctx context.Context) {
+	out, err := w.run(ctx, fmt.Sprintf("kubectl --kubeconfig %v get pods -n prefetch -o json", w.kubeConfig))
+	if err != nil {
+		return
+	}
+	var pods struct {
+		Items []struct {
+			Metadata struct {
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+			Spec struct {
+				NodeName string `json:"nodeName"`
+			} `json:"spec"`
+			Status struct {
+				InitContainerStatuses []struct {
+					Name  string `json:"name"`
+					State struct {
+						Waiting *struct {
+							Reason  string `json:"reason"`
+							Message string `json:"message"`
+						} `json:"waiting"`
+					} `json:"state"`
+				} `json:"initContainerStatuses"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err = json.Unmarshal([]byte(out), &pods); err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, pod := range pods.Items {
+		// Note: statuses of init containers are in the order they start
+		var blocking *pullFailure
+		for _, c := range pod.Status.InitContainerStatuses {
+			var image, ok = w.images[c.Name]
+			if !ok {
+				continue
+			}
+			if blocking != nil {
+				if c.State.Waiting != nil {
+					blocking.NotAttempted = append(blocking.NotAttempted, image)
+				}
+				continue
+			}
+			var key = pod.Spec.NodeName + "/" + c.Name
+			if c.State.Waiting == nil || !pullFailureReasons[c.State.Waiting.Reason] {
+				if c.State.Waiting == nil {
+					delete(w.failing, key)
+				}
+				continue
+			}
+			var f = &pullFailure{
+				DaemonSet: pod.Metadata.Labels["app"],
+				Node:      pod.Spec.NodeName,
+				Image:     image,
+				Container: c.Name,
+				Reason:    c.State.Waiting.Reason,
+				Message:   c.State.Waiting.Message,
+			}
+			if prev, seen := w.failing[key]; !seen || prev.Reason != f.Reason {
+				f.log(w.cluster).Warn("Image is failing to prefetch")
+			}
+			w.failing[key] = f
+			blocking = f
+		}
+	}
+}
+
+// resolve forgets failures of the DaemonSet that has been rolled out.
+func (w *pullWatcher) resolve(daemonSet string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for key, f := range w.failing {
+		if f.DaemonSet == daemonSet {
+			delete(w.failing, key)
+		}
+	}
+}
+
+// failures returns images that are still failing sorted by images and nodes.
+func (w *pullWatcher) failures() []pullFailure {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var result = make([]pullFailure, 0, len(w.failing))
+	for _, f := range w.failing {
+		result = append(result, *f)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Image != result[j].Image {
+			return result[i].Image < result[j].Image
+		}
+		return result[i].Node < result[j].Node
+	})
+	return result
+}
+
+func (f *pullFailure) log(cluster string) *logrus.Entry {
+	var fields = logrus.Fields{
+		"cluster":   cluster,
+		"node":      f.Node,
+		"image":     f.Image,
+		"daemonset": f.DaemonSet,
+		"reason":    f.Reason,
+		"message":   f.Message,
+	}
+	if len(f.NotAttempted) > 0 {
+		fields["notAttempted"] = strings.Join(f.NotAttempted, ",")
+	}
+	return logrus.WithFields(fields)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ContainerName_ShouldBeReadableDNSLabel(t *testing.T) {
+	var dnsLabel = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+	var names = make(map[string]bool)
+	for _, image := range []string{
+		"ghcr.io/networkservicemesh/cmd-nsc:v1.0.0",
+		"registry.local:5000/cmd-nsc:v1.0.0",
+		"alpine@sha256:c5b1261d",
+		"registry.local:5000/" + strings.Repeat("very-long-image-name-", 5) + ":v1",
+		"___",
+	} {
+		var name = containerName(image)
+		require.Regexp(t, dnsLabel, name, image)
+		require.False(t, names[name], image)
+		names[name] = true
+	}
+	require.True(t, strings.HasPrefix(containerName("ghcr.io/networkservicemesh/cmd-nsc:v1.0.0"), "cmd-nsc-v1-0-0-"))
+}
+
+const prefetchPodsJSON = `{"items": [
+	{"metadata": {"labels": {"app": "prefetch-0"}}, "spec": {"nodeName": "worker-1"}, "status": {"initContainerStatuses": [
+		{"name": "return", "state": {"terminated": {"exitCode": 0}}},
+		{"name": "cmd-nsc", "state": {"waiting": {"reason": "%v", "message": "manifest unknown"}}},
+		{"name": "alpine", "state": {"waiting": {"reason": "PodInitializing"}}}
+	]}},
+	{"metadata": {"labels": {"app": "prefetch-0"}}, "spec": {"nodeName": "worker-2"}, "status": {"initContainerStatuses": [
+		{"name": "cmd-nsc", "state": {"running": {}}}
+	]}}
+]}`
+
+func Test_PullWatcher_ShouldReportFailingImages(t *testing.T) {
+	var reason = "ErrImagePull"
+	var w = newPullWatcher("cluster0", "/kubeconfig", map[string]string{"cmd-nsc": "ghcr.io/networkservicemesh/cmd-nsc:v1.0.0", "alpine": "alpine:3.19"})
+	w.run = func(_ context.Context, cmd string) (string, error) {
+		require.Equal(t, "kubectl --kubeconfig /kubeconfig get pods -n prefetch -o json", cmd)
+		return fmt.Sprintf(prefetchPodsJSON, reason), nil
+	}
+
+	w.poll(context.Background())
+	reason = "ImagePullBackOff"
+	w.poll(context.Background())
+
+	require.Equal(t, []pullFailure{{
+		DaemonSet:    "prefetch-0",
+		Node:         "worker-1",
+		Image:        "ghcr.io/networkservicemesh/cmd-nsc:v1.0.0",
+		Container:    "cmd-nsc",
+		Reason:       "ImagePullBackOff",
+		Message:      "manifest unknown",
+		NotAttempted: []string{"alpine:3.19"},
+	}}, w.failures())
+
+	reason = "PodInitializing"
+	w.poll(context.Background())
+	require.Len(t, w.failures(), 1, "waiting for other reasons shouldn't resolve the failure")
+
+	w.resolve("prefetch-0")
+	require.Empty(t, w.failures())
+}
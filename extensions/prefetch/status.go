@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// clusterStatus is a status of the prefetch on the cluster.
+type clusterStatus struct {
+	Cluster    string
+	KubeConfig string
+	Backend    string
+	// Total is a number of DaemonSets for daemonset backend or a number of images for kind backend.
+	Total int
+	// Done is a number of rolled out DaemonSets or loaded images. It is updated concurrently.
+	Done     int32
+	Duration time.Duration
+	Err      error
+	// Failures are images that nodes failed to pull by the end of the rollout. Images of the same pod after
+	// a failing image are not attempted, they are reported with the failure.
+	Failures []pullFailure
+}
+
+func (s *clusterStatus) report(images int) {
+	var entry = logrus.WithFields(logrus.Fields{
+		"cluster":    s.Cluster,
+		"kubeconfig": s.KubeConfig,
+		"images":     images,
+		"backend":    s.Backend,
+		"duration":   s.Duration,
+		"failed":     len(s.Failures),
+	})
+	for i := range s.Failures {
+		if len(s.Failures[i].NotAttempted) > 0 {
+			s.Failures[i].log(s.Cluster).Errorf("Image has failed to prefetch, %v next images of the pod were not attempted", len(s.Failures[i].NotAttempted))
+			continue
+		}
+		s.Failures[i].log(s.Cluster).Error("Image has failed to prefetch")
+	}
+	if done := int(atomic.LoadInt32(&s.Done)); done < s.Total || s.Err != nil {
+		entry.Errorf("Prefetch has failed: %v of %v are done. Error: %v", done, s.Total, s.Err)
+		return
+	}
+	entry.Info("Prefetch has completed")
+}
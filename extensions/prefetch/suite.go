@@ -1,6 +1,6 @@
 // Copyright (c) 2021 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2022 Cisco and/or its affiliates.
+// Copyright (c) 2022-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -20,37 +20,152 @@
 package prefetch
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 
 	"github.com/networkservicemesh/gotestmd/pkg/suites/shell"
+	"github.com/networkservicemesh/integration-tests/extensions/logs"
 	"github.com/networkservicemesh/integration-tests/extensions/prefetch/images"
 )
 
 // Config is env config to setup images prefetching.
 type Config struct {
-	ImagesPerDaemonset int    `default:"10" desc:"Number of images created per DaemonSet" split_words:"true"`
-	Timeout            string `default:"10m" desc:"Kubectl rollout status timeout for the DaemonSet" split_words:"true"`
+	ImagesPerDaemonset int           `default:"10" desc:"Number of images created per DaemonSet" split_words:"true"`
+	Timeout            string        `default:"10m" desc:"Kubectl rollout status timeout for the DaemonSet" split_words:"true"`
+	Backend            string        `default:"daemonset" desc:"How images get to nodes: daemonset pulls them on nodes, kind pulls them once on the host and loads into kind node containers"`
+	Runtime            string        `default:"docker" desc:"CLI of the container runtime running kind nodes" split_words:"true"`
+	Scope              string        `default:"suite" desc:"Images to prefetch: suite - images deployed by the running suite and its parents, full - images of all sources"`
+	Strict             bool          `default:"false" desc:"Boolean variable which fails the suite if a source of images fails or yields no images"`
+	CacheDir           string        `default:"" desc:"Dir of the cache of images resolved from GitHub sources. Defaults to the user cache dir, - disables the cache" split_words:"true"`
+	PollInterval       time.Duration `default:"10s" desc:"Interval of polling statuses of prefetch containers to find images failing to pull" split_words:"true"`
+	CacheTTL           time.Duration `default:"1h" desc:"Time to live of cached images of branches and tags. Images of commits never expire" split_words:"true"`
 }
 
+const (
+	suiteScope = "suite"
+	fullScope  = "full"
+)
+
 // Suite creates `prefetch` daemonset which pulls all test images for all cluster nodes.
 type Suite struct {
 	shell.Suite
+	// CommonSourcesURLs are sources of images used by every suite, e.g. external images. They are prefetched in any scope.
+	CommonSourcesURLs []string
+	// SourcesURLs are sources of the full image set. They are used if images of the running suite are not found.
 	SourcesURLs []string
+	// Checkout is the checkout of the repository that steps of suites deploy from.
+	Checkout Checkout
+	// SuiteDir is the dir of the generated suite file of the running suite. Images of all sources are prefetched if it is empty.
+	SuiteDir string
 }
 
-var once sync.Once
+var (
+	prefetchedMu sync.Mutex
+	// prefetched are images prefetched by suites of the process. Suites share images with their parents and other suites,
+	// so each suite prefetches only images that are not prefetched yet.
+	prefetched = make(map[string]bool)
+)
 
 // SetupSuite prefetches docker images for each k8s node.
 func (s *Suite) SetupSuite() {
-	once.Do(s.initialize)
+	s.initialize()
+}
+
+// claimImages returns images that are not prefetched yet and marks them as prefetched.
+func claimImages(images []string) []string {
+	prefetchedMu.Lock()
+	defer prefetchedMu.Unlock()
+
+	var result []string
+	for _, image := range images {
+		if !prefetched[image] {
+			prefetched[image] = true
+			result = append(result, image)
+		}
+	}
+	return result
+}
+
+// releaseImages marks images as not prefetched, so next suites try them again.
+func releaseImages(images []string) {
+	prefetchedMu.Lock()
+	defer prefetchedMu.Unlock()
+
+	for _, image := range images {
+		delete(prefetched, image)
+	}
+}
+
+// sources returns image sources of the scope. Images of the running suite are taken from resources applied by its steps
+// and steps of its parents. Step sources are returned separately too, they may have no images.
+func (s *Suite) sources(scope string) (sources, stepSources []string) {
+	sources = append(sources, s.CommonSourcesURLs...)
+	switch scope {
+	case fullScope:
+		return append(sources, s.SourcesURLs...), nil
+	case suiteScope:
+	default:
+		require.Failf(s.T(), "unknown prefetch scope", "%v, expected %v or %v", scope, suiteScope, fullScope)
+	}
+
+	var files []string
+	var err = errors.New("dir of the suite is unknown")
+	if s.SuiteDir != "" {
+		var root string
+		if root, err = ModuleRoot(s.SuiteDir); err == nil {
+			files, err = SuiteFiles(s.SuiteDir, root)
+		}
+	}
+	if err == nil {
+		stepSources, err = StepSources(files, s.Checkout)
+	}
+	if err != nil || len(stepSources) == 0 {
+		logrus.Warnf("Images of the suite are not found, images of all sources are prefetched. Error: %v", err)
+		return append(sources, s.SourcesURLs...), nil
+	}
+	return append(sources, stepSources...), stepSources
+}
+
+func cacheOptions(config *Config) []images.Option {
+	var dir = config.CacheDir
+	if dir == "-" {
+		return nil
+	}
+	if dir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			logrus.Warnf("Images are not cached. Error: %s", err.Error())
+			return nil
+		}
+		dir = filepath.Join(userCacheDir, "networkservicemesh", "prefetch")
+	}
+	return []images.Option{images.WithCache(dir, config.CacheTTL)}
+}
+
+// images returns images of the scope that are not prefetched yet by other suites of the process.
+func (s *Suite) images(config *Config) []string {
+	sources, stepSources := s.sources(config.Scope)
+	list, err := images.ReteriveList(sources, func(s string) bool {
+		return strings.HasSuffix(s, ".yaml") && !IsExcluded(s)
+	}, append(cacheOptions(config), images.WithOptionalSources(stepSources...))...)
+	if err != nil {
+		require.False(s.T(), config.Strict, "Prefetch sources have failed. Error: %s", err)
+		logrus.Warnf("Images of failed sources are not prefetched. Error: %s", err.Error())
+	}
+
+	return claimImages(removeDuplicates(list.Images))
 }
 
 func (s *Suite) initialize() {
@@ -58,11 +173,16 @@ func (s *Suite) initialize() {
 	require.NoError(s.T(), envconfig.Usage("prefetch", &config))
 	require.NoError(s.T(), envconfig.Process("prefetch", &config))
 
-	prefetchImages := images.ReteriveList(s.SourcesURLs, func(s string) bool {
-		return strings.HasSuffix(s, ".yaml") && !IsExcluded(s)
-	}).Images
-
-	prefetchImages = removeDuplicates(prefetchImages)
+	prefetchImages := s.images(&config)
+	if len(prefetchImages) == 0 {
+		logrus.Info("Images of the suite are already prefetched")
+		return
+	}
+	defer func() {
+		if s.T().Failed() {
+			releaseImages(prefetchImages)
+		}
+	}()
 
 	wd, err := os.Getwd()
 	require.NoError(s.T(), err)
@@ -70,39 +190,150 @@ func (s *Suite) initialize() {
 	require.NoError(s.T(), os.MkdirAll(tmpDir, 0o750))
 	s.T().Cleanup(func() { _ = os.RemoveAll(tmpDir) })
 
-	r := s.Runner(tmpDir)
+	timeout, err := time.ParseDuration(config.Timeout)
+	require.NoError(s.T(), err)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var loader *kindLoader
+	switch config.Backend {
+	case daemonSetBackend:
+	case kindBackend:
+		loader = newKindLoader(config.Runtime)
+	default:
+		require.Failf(s.T(), "unknown prefetch backend", "%v, expected %v or %v", config.Backend, daemonSetBackend, kindBackend)
+	}
+
+	var kubeConfigs = logs.KubeConfigs()
+	var statuses = make([]*clusterStatus, len(kubeConfigs))
+	var wg sync.WaitGroup
+	for i, kubeConfig := range kubeConfigs {
+		statuses[i] = &clusterStatus{Cluster: logs.ClusterName(kubeConfig), KubeConfig: kubeConfig, Backend: daemonSetBackend}
+		wg.Add(1)
+		go func(status *clusterStatus) {
+			defer wg.Done()
+			var start = time.Now()
+			defer func() { status.Duration = time.Since(start) }()
+
+			if loader != nil {
+				nodes, nodesErr := loader.Nodes(ctx, status.KubeConfig)
+				if nodesErr == nil && len(nodes) > 0 {
+					inv, invErr := loader.Inventory(ctx, nodes)
+					if invErr != nil {
+						logrus.Warnf("Images of %v nodes are unknown, all images are loaded. Error: %s", status.Cluster, invErr.Error())
+					}
+					var missing = inv.missing(prefetchImages)
+					inv.report(status.Cluster, prefetchImages)
+					status.Backend, status.Total = kindBackend, len(missing)
+					status.Err = loader.Load(ctx, nodes, missing, inv, status)
+					return
+				}
+				logrus.Warnf("%v is not a kind cluster, DaemonSets are used for prefetch. Error: %v", status.Cluster, nodesErr)
+			}
+			inv, invErr := nodeInventory(ctx, runShell, status.KubeConfig)
+			if invErr != nil {
+				logrus.Warnf("Images of %v nodes are unknown, all images are prefetched. Error: %s", status.Cluster, invErr.Error())
+			}
+			var missing = inv.missing(prefetchImages)
+			inv.report(status.Cluster, prefetchImages)
+			status.Err = s.prefetch(ctx, filepath.Join(tmpDir, status.Cluster), missing, &config, status)
+		}(statuses[i])
+	}
+	wg.Wait()
+
+	// Note: goroutines of clusters can't fail the test, so it fails here once with errors of all clusters
+	var errs []error
+	for _, status := range statuses {
+		status.report(len(prefetchImages))
+		if status.Err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", status.Cluster, status.Err))
+		}
+	}
+	require.NoError(s.T(), errors.Join(errs...))
+}
+
+// prefetch creates DaemonSets pulling the images in the dir and rolls them out to the cluster concurrently.
+// It runs out of the test goroutine, so commands return errors instead of failing the test.
+func (s *Suite) prefetch(ctx context.Context, dir string, prefetchImages []string, config *Config, status *clusterStatus) error {
+	var kubectl = fmt.Sprintf("kubectl --kubeconfig %v", status.KubeConfig)
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	run := func(cmd string) error {
+		_, err := runShell(ctx, fmt.Sprintf("cd %v && %v", dir, cmd))
+		return err
+	}
 
 	var daemonSets []string
+	var containerImages = make(map[string]string)
 	for d := 0; d*config.ImagesPerDaemonset < len(prefetchImages); d++ {
 		var containers string
 		for c := 0; c < config.ImagesPerDaemonset && d*config.ImagesPerDaemonset+c < len(prefetchImages); c++ {
-			containers += container(uuid.NewString(), prefetchImages[d*config.ImagesPerDaemonset+c])
+			var image = prefetchImages[d*config.ImagesPerDaemonset+c]
+			var name = containerName(image)
+			containerImages[name] = image
+			containers += container(name, image)
 		}
 
-		r.Run(createDaemonSet(d, containers))
+		if err := run(createDaemonSet(d, containers)); err != nil {
+			return err
+		}
 
 		daemonSets = append(daemonSets, fmt.Sprintf("prefetch-%d", d))
 	}
+	status.Total = len(daemonSets)
+	if len(daemonSets) == 0 {
+		return nil
+	}
 
-	r.Run("kubectl create ns prefetch")
+	if err := run(kubectl + " create ns prefetch"); err != nil {
+		return err
+	}
 	s.T().Cleanup(func() {
-		r.Run("kubectl describe pods -n prefetch")
-		r.Run("kubectl delete ns prefetch")
+		r := s.Runner(dir)
+		if logs.ShouldCollect(s.T().Failed()) {
+			r.Run(kubectl + " describe pods -n prefetch")
+		}
+		r.Run(kubectl + " delete ns prefetch")
 	})
 
+	// Note: rollout status doesn't tell which image fails, so container statuses are polled during the rollout
+	var watcher = newPullWatcher(status.Cluster, status.KubeConfig, containerImages)
+	watchCtx, cancelWatch := context.WithCancel(context.Background())
+	var watchDone = make(chan struct{})
+	go func() {
+		defer close(watchDone)
+		watcher.watch(watchCtx, config.PollInterval)
+	}()
+	defer func() {
+		cancelWatch()
+		<-watchDone
+		status.Failures = watcher.failures()
+	}()
+
 	var wg sync.WaitGroup
-	for _, daemonSet := range daemonSets {
+	var errs = make([]error, len(daemonSets))
+	for i, daemonSet := range daemonSets {
 		wg.Add(1)
-		go func(daemonSet string) {
+		go func(i int, daemonSet string) {
 			defer wg.Done()
 
-			dr := s.Runner(tmpDir)
-			dr.Run(fmt.Sprintf("kubectl -n prefetch apply -f %s.yaml", daemonSet))
-			dr.Run(fmt.Sprintf("kubectl -n prefetch rollout status daemonset/%s --timeout=%s", daemonSet, config.Timeout))
-			dr.Run(fmt.Sprintf("kubectl -n prefetch delete -f %s.yaml", daemonSet))
-		}(daemonSet)
+			if errs[i] = run(fmt.Sprintf("%s -n prefetch apply -f %s.yaml", kubectl, daemonSet)); errs[i] != nil {
+				return
+			}
+			if errs[i] = run(fmt.Sprintf("%s -n prefetch rollout status daemonset/%s --timeout=%s", kubectl, daemonSet, config.Timeout)); errs[i] != nil {
+				return
+			}
+			watcher.resolve(daemonSet)
+			if errs[i] = run(fmt.Sprintf("%s -n prefetch delete -f %s.yaml", kubectl, daemonSet)); errs[i] != nil {
+				return
+			}
+			atomic.AddInt32(&status.Done, 1)
+		}(i, daemonSet)
 	}
 	wg.Wait()
+	return errors.Join(errs...)
 }
 
 func removeDuplicates(source []string) []string {
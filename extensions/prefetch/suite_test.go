@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prefetch
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Suite_ShouldPrefetchImagesOfEachSuiteOnce(t *testing.T) {
+	var root = filepath.Join(t.TempDir(), "integration-tests")
+	var checkout = Checkout{
+		Root:          root,
+		RepositoryURL: "https://github.com/networkservicemesh/deployments-k8s",
+		Dir:           filepath.Join(filepath.Dir(root), "deployments-k8s"),
+	}
+	writeFile(t, filepath.Join(root, "go.mod"), "module github.com/networkservicemesh/integration-tests\n\ngo 1.23\n")
+	writeFile(t, filepath.Join(checkout.Dir, "examples/first/client.yaml"), "images:\n  - alpine:3.19\n  - busybox:1.36\n")
+	writeFile(t, filepath.Join(checkout.Dir, "examples/second/client.yaml"), "images:\n  - busybox:1.36\n  - nicolaka/netshoot\n")
+	for _, name := range []string{"first", "second"} {
+		writeFile(t, filepath.Join(root, "suites", name, GeneratedSuiteFile), "package "+name+"\n"+
+			"func (s *Suite) SetupSuite() {\n"+
+			"	r := s.Runner(\"../deployments-k8s/examples/"+name+"\")\n"+
+			"	r.Run(`kubectl apply -f client.yaml`)\n"+
+			"}\n")
+	}
+
+	// Note: suites run from the dir of the test which has no generated suite
+	var config = Config{Scope: suiteScope, CacheDir: "-"}
+	var first = &Suite{Checkout: checkout, SuiteDir: filepath.Join(root, "suites", "first")}
+	first.SetT(t)
+	require.Equal(t, []string{"alpine:3.19", "busybox:1.36"}, first.images(&config))
+
+	var second = &Suite{Checkout: checkout, SuiteDir: filepath.Join(root, "suites", "second")}
+	second.SetT(t)
+	require.Equal(t, []string{"nicolaka/netshoot"}, second.images(&config))
+	require.Empty(t, first.images(&config))
+
+	releaseImages([]string{"alpine:3.19", "busybox:1.36", "nicolaka/netshoot"})
+	require.Equal(t, []string{"busybox:1.36", "nicolaka/netshoot"}, second.images(&config))
+	releaseImages([]string{"busybox:1.36", "nicolaka/netshoot"})
+}